@@ -6,6 +6,8 @@ package bastion
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 )
 
 type CreationInformation struct {
@@ -20,6 +22,12 @@ type LastActivity struct {
 	Ago       string `json:"ago"`
 }
 
+type FreezeInfo struct {
+	Reason    *string `json:"reason"`
+	By        string  `json:"by"`
+	Timestamp int     `json:"timestamp"`
+}
+
 type IngressPIVGrace struct {
 	Enabled             BoolFromInt `json:"enabled"`
 	ExpirationTimestamp int         `json:"expiration_timestamp"`
@@ -28,36 +36,43 @@ type IngressPIVGrace struct {
 
 // Account represents a Bastion account.
 type Account struct {
-	Account                   string              `json:"account"`
-	MFATOTPBypass             BoolFromInt         `json:"mfa_totp_bypass"`
-	MFATOTPRequired           BoolFromInt         `json:"mfa_totp_required"`
-	MFATOTPConfigured         BoolFromInt         `json:"mfa_totp_configured"`
-	MFAPasswordBypass         BoolFromInt         `json:"mfa_password_bypass"`
-	MFAPasswordRequired       BoolFromInt         `json:"mfa_password_required"`
-	MFAPasswordConfigured     BoolFromInt         `json:"mfa_password_configured"`
-	GlobalIngressPolicy       BoolFromInt         `json:"global_ingress_policy"`
-	IsExpired                 BoolFromInt         `json:"is_expired"`
-	PersonalEgressMFARequired MFARequiredPolicy   `json:"personal_egress_mfa_required"`
-	CreationInformation       CreationInformation `json:"creation_information"`
-	AllowedCommands           []string            `json:"allowed_commands"`
-	IngressPIVPolicy          PIVPolicy           `json:"ingress_piv_policy"`
-	IngressPIVEnforced        BoolFromInt         `json:"ingress_piv_enforced"`
-	IngressPIVGrace           IngressPIVGrace     `json:"ingress_piv_grace"`
-	CanConnect                BoolFromInt         `json:"can_connect"`
-	AlreadySeenBefore         BoolFromInt         `json:"already_seen_before"`
-	IsActive                  BoolFromInt         `json:"is_active"`
-	AlwaysActive              BoolFromInt         `json:"always_active"`
-	MaxInactiveDays           string              `json:"max_inactive_days"`
-	IsFrozen                  BoolFromInt         `json:"is_frozen"`
-	OshOnly                   BoolFromInt         `json:"osh_only"`
-	IsAdmin                   BoolFromInt         `json:"is_admin"`
-	IsSuperOwner              BoolFromInt         `json:"is_super_owner"`
-	IsAuditor                 BoolFromInt         `json:"is_auditor"`
-	IsTTLSet                  BoolFromInt         `json:"is_ttl_set"`
-	IsTTLExpired              BoolFromInt         `json:"is_ttl_expired"`
-	TTTLTimestamp             int                 `json:"ttl_timestamp"`
-	IdleIgnore                BoolFromInt         `json:"idle_ignore"`
-	PamAuthBypass             BoolFromInt         `json:"pam_auth_bypass"`
+	Account                     string                             `json:"account"`
+	UID                         int                                `json:"uid"`
+	MFATOTPBypass               BoolFromInt                        `json:"mfa_totp_bypass"`
+	MFATOTPRequired             BoolFromInt                        `json:"mfa_totp_required"`
+	MFATOTPConfigured           BoolFromInt                        `json:"mfa_totp_configured"`
+	MFAPasswordBypass           BoolFromInt                        `json:"mfa_password_bypass"`
+	MFAPasswordRequired         BoolFromInt                        `json:"mfa_password_required"`
+	MFAPasswordConfigured       BoolFromInt                        `json:"mfa_password_configured"`
+	GlobalIngressPolicy         BoolFromInt                        `json:"global_ingress_policy"`
+	IsExpired                   BoolFromInt                        `json:"is_expired"`
+	PersonalEgressMFARequired   MFARequiredPolicy                  `json:"personal_egress_mfa_required"`
+	CreationInformation         CreationInformation                `json:"creation_information"`
+	AllowedCommands             []string                           `json:"allowed_commands"`
+	IngressPIVPolicy            PIVPolicy                          `json:"ingress_piv_policy"`
+	IngressPIVEnforced          BoolFromInt                        `json:"ingress_piv_enforced"`
+	IngressPIVGrace             IngressPIVGrace                    `json:"ingress_piv_grace"`
+	CanConnect                  BoolFromInt                        `json:"can_connect"`
+	AlreadySeenBefore           BoolFromInt                        `json:"already_seen_before"`
+	IsActive                    BoolFromInt                        `json:"is_active"`
+	AlwaysActive                BoolFromInt                        `json:"always_active"`
+	AlwaysActiveReason          *string                            `json:"always_active_reason"`
+	LastActivity                *LastActivity                      `json:"last_activity"`
+	MaxInactiveDays             string                             `json:"max_inactive_days"`
+	IsFrozen                    BoolFromInt                        `json:"is_frozen"`
+	FreezeInfo                  *FreezeInfo                        `json:"freeze_info"`
+	OshOnly                     BoolFromInt                        `json:"osh_only"`
+	IsAdmin                     BoolFromInt                        `json:"is_admin"`
+	IsSuperOwner                BoolFromInt                        `json:"is_super_owner"`
+	IsAuditor                   BoolFromInt                        `json:"is_auditor"`
+	IsTTLSet                    BoolFromInt                        `json:"is_ttl_set"`
+	IsTTLExpired                BoolFromInt                        `json:"is_ttl_expired"`
+	TTTLTimestamp               int                                `json:"ttl_timestamp"`
+	IdleIgnore                  BoolFromInt                        `json:"idle_ignore"`
+	PamAuthBypass               BoolFromInt                        `json:"pam_auth_bypass"`
+	PubkeyAuthOptional          BoolFromInt                        `json:"pubkey_auth_optional"`
+	EgressStrictHostKeyChecking *EgressStrictHostKeyCheckingPolicy `json:"egress_strict_host_key_checking"`
+	EgressSessionMultiplexing   *YesNoDefault                      `json:"egress_session_multiplexing"`
 }
 
 func (c *Client) AccountInfo(name string) (*Account, error) {
@@ -111,7 +126,7 @@ type CreateAccountOptions struct {
 	Comment         string
 	PublicKey       string
 	NoKey           bool
-	TTL             int
+	TTL             Duration
 }
 
 func (c *CreateAccountOptions) validate() error {
@@ -146,7 +161,7 @@ func (c *CreateAccountOptions) toArgs() []string {
 		args = append(args, "--no-key")
 	}
 	if c.TTL != 0 {
-		args = append(args, "--ttl", fmt.Sprintf("%ds", c.TTL))
+		args = append(args, "--ttl", c.TTL.String())
 	}
 
 	return args
@@ -282,6 +297,55 @@ func (c *Client) DeleteAccount(name string) error {
 	return nil
 }
 
+// AccountFreeze freezes a Bastion account, preventing it from connecting until unfrozen.
+func (c *Client) AccountFreeze(account, reason string) error {
+	args := []string{"--account", account}
+	if reason != "" {
+		args = append(args, "--reason", fmt.Sprintf("%q", reason))
+	}
+	_, err := c.executeCommand("accountFreeze", args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// AccountUnfreeze unfreezes a previously frozen Bastion account.
+func (c *Client) AccountUnfreeze(account string) error {
+	_, err := c.executeCommand("accountUnfreeze", "--account", account)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// AccountUnexpire reactivates a Bastion account which expired because of inactivity.
+func (c *Client) AccountUnexpire(account string) error {
+	_, err := c.executeCommand("accountUnexpire", "--account", account)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// AccountMFAResetTOTP removes the TOTP enrollment of a Bastion account.
+func (c *Client) AccountMFAResetTOTP(account string) error {
+	_, err := c.executeCommand("accountMFAResetTOTP", "--account", account)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// AccountMFAResetPassword removes the MFA password of a Bastion account.
+func (c *Client) AccountMFAResetPassword(account string) error {
+	_, err := c.executeCommand("accountMFAResetPassword", "--account", account)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 // AccuntGrantCommand grants a command to a Bastion account.
 func (c *Client) AccountGrantCommand(account, command string) error {
 	_, err := c.executeCommand("accountGrantCommand", "--account", account, "--command", command)
@@ -314,11 +378,106 @@ func (c *Client) AccountSetPIVPolicy(account string, policy PIVPolicy) error {
 }
 
 // AccountSetPIVGrace sets the PIV grace policy for an account with a TTL.
-// The ttl parameter is in seconds.
-func (c *Client) AccountSetPIVGrace(account string, ttl int) error {
-	_, err := c.executeCommand("accountPIV", "--account", account, "--policy", "grace", "--ttl", fmt.Sprintf("%d", ttl))
+func (c *Client) AccountSetPIVGrace(account string, ttl Duration) error {
+	_, err := c.executeCommand("accountPIV", "--account", account, "--policy", "grace", "--ttl", ttl.String())
 	if err != nil {
 		return err
 	}
 	return nil
 }
+
+// AccountListEgressKeys lists the egress public keys of an account.
+func (c *Client) AccountListEgressKeys(account string) ([]Key, error) {
+	response, err := c.executeCommand("accountListEgressKeys", "--account", account)
+	if err != nil {
+		return nil, err
+	}
+
+	valueBytes, err := json.Marshal(response.Value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response value: %w", err)
+	}
+
+	// the keys are returned as a map indexed by fingerprint
+	var keyMap map[string]Key
+	if err := json.Unmarshal(valueBytes, &keyMap); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal egress keys: %w", err)
+	}
+
+	keys := make([]Key, 0, len(keyMap))
+	for _, key := range keyMap {
+		keys = append(keys, key)
+	}
+	sortKeys(keys)
+
+	return keys, nil
+}
+
+// AccountListOptions holds options for listing Bastion accounts.
+type AccountListOptions struct {
+	// InactiveOnly only lists the accounts which are inactive.
+	InactiveOnly bool
+	// Audit includes the detailed account information (activity, expiration, flags...).
+	Audit bool
+	// Include only lists the accounts matching one of these shell-like patterns.
+	Include []string
+	// Exclude omits the accounts matching one of these shell-like patterns.
+	Exclude []string
+}
+
+func (a *AccountListOptions) toArgs() []string {
+	args := []string{}
+	if a.InactiveOnly {
+		args = append(args, "--inactive-only")
+	}
+	if a.Audit {
+		args = append(args, "--audit")
+	}
+	for _, pattern := range a.Include {
+		args = append(args, "--include", fmt.Sprintf("%q", pattern))
+	}
+	for _, pattern := range a.Exclude {
+		args = append(args, "--exclude", fmt.Sprintf("%q", pattern))
+	}
+	return args
+}
+
+// AccountList lists the Bastion accounts, sorted by name.
+func (c *Client) AccountList(listOpts *AccountListOptions) ([]*Account, error) {
+	args := []string{}
+	if listOpts != nil {
+		args = append(args, listOpts.toArgs()...)
+	}
+
+	response, err := c.executeCommand("accountList", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	valueBytes, err := json.Marshal(response.Value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response value: %w", err)
+	}
+
+	// the accounts are returned as a map indexed by account name
+	var accountMap map[string]*Account
+	if err := json.Unmarshal(valueBytes, &accountMap); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal account list: %w", err)
+	}
+
+	accounts := make([]*Account, 0, len(accountMap))
+	for name, account := range accountMap {
+		if account == nil {
+			account = &Account{}
+		}
+		if account.Account == "" {
+			account.Account = name
+		}
+		accounts = append(accounts, account)
+	}
+	slices.SortFunc(accounts, func(a, b *Account) int {
+		return strings.Compare(a.Account, b.Account)
+	})
+
+	return accounts, nil
+}
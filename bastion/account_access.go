@@ -3,7 +3,12 @@
 
 package bastion
 
-import "encoding/json"
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+)
 
 type AccountAccess struct {
 	AccessType string  `json:"type"` // "personal", "group" or "group-guest"
@@ -50,3 +55,176 @@ func (c *Client) AccountListAccesses(account string) ([]*AccountAccess, error) {
 
 	return accesses, nil
 }
+
+// AccountAddPersonalAccessOptions represents options for adding a personal access to an account.
+type AccountAddPersonalAccessOptions struct {
+	ForceKey      string
+	ForcePassword string
+	TTL           Duration
+	Comment       string
+	Protocol      string
+	ProxyOptions  *ProxyOptions
+	RemotePort    *int
+}
+
+func (a *AccountAddPersonalAccessOptions) validate() error {
+	if a.ProxyOptions != nil {
+		if err := a.ProxyOptions.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *AccountAddPersonalAccessOptions) toArgs() []string {
+	var args []string
+	if a.ForceKey != "" {
+		args = append(args, "--force-key", a.ForceKey)
+	}
+	if a.ForcePassword != "" {
+		args = append(args, "--force-password", a.ForcePassword)
+	}
+	if a.TTL != 0 {
+		args = append(args, "--ttl", a.TTL.String())
+	}
+	if a.Comment != "" {
+		args = append(args, "--comment", fmt.Sprintf("%q", a.Comment))
+	}
+	if a.Protocol != "" {
+		args = append(args, "--protocol", a.Protocol)
+	}
+	if a.ProxyOptions != nil {
+		args = append(args, a.ProxyOptions.toArgs()...)
+	}
+	if a.RemotePort != nil {
+		args = append(args, "--remote-port", fmt.Sprintf("%d", *a.RemotePort))
+	}
+	return args
+}
+
+// AccountAddPersonalAccess adds a personal access to an account.
+func (c *Client) AccountAddPersonalAccess(account, host, port, user string, options *AccountAddPersonalAccessOptions) (*ACL, error) {
+	args := []string{"--account", account, "--host", host, "--port", fmt.Sprintf("%q", port)}
+	if user != "" {
+		args = append(args, "--user", fmt.Sprintf("%q", user))
+	}
+	if options != nil {
+		if err := options.validate(); err != nil {
+			return nil, err
+		}
+		args = append(args, options.toArgs()...)
+	}
+	response, err := c.executeCommand("accountAddPersonalAccess", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	valueBytes, err := json.Marshal(response.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	var acl ACL
+	if err := json.Unmarshal(valueBytes, &acl); err != nil {
+		return nil, err
+	}
+	return &acl, nil
+}
+
+// AccountDelPersonalAccess removes a personal access from an account.
+func (c *Client) AccountDelPersonalAccess(account, host, port, user, protocol string, proxyOpts *ProxyOptions, remotePort *int64) error {
+	args := []string{"--account", account, "--host", host, "--port", fmt.Sprintf("%q", port)}
+	if user != "" {
+		args = append(args, "--user", fmt.Sprintf("%q", user))
+	}
+	if protocol != "" {
+		args = append(args, "--protocol", protocol)
+	}
+	if proxyOpts != nil {
+		if err := proxyOpts.validate(); err != nil {
+			return err
+		}
+		args = append(args, proxyOpts.toArgs()...)
+	}
+	if remotePort != nil && *remotePort != 0 {
+		args = append(args, "--remote-port", fmt.Sprintf("%d", *remotePort))
+	}
+	_, err := c.executeCommand("accountDelPersonalAccess", args...)
+	return err
+}
+
+// WhoHasAccessTo represents an account having access to a server, as returned by whoHasAccessTo.
+type WhoHasAccessTo struct {
+	Account    string  `json:"account"`
+	AccessType string  `json:"type"` // "personal", "group" or "group-guest"
+	Group      *string `json:"group"`
+	ACL        []ACL   `json:"acl"`
+}
+
+// WhoHasAccessToOptions represents options for looking up the accounts having access to a server.
+type WhoHasAccessToOptions struct {
+	Port           string
+	User           string
+	IgnorePersonal bool
+	IgnoreGroup    bool
+	ShowWildcard   bool
+}
+
+func (w *WhoHasAccessToOptions) toArgs() []string {
+	var args []string
+	if w.Port != "" {
+		args = append(args, "--port", fmt.Sprintf("%q", w.Port))
+	}
+	if w.User != "" {
+		args = append(args, "--user", fmt.Sprintf("%q", w.User))
+	}
+	if w.IgnorePersonal {
+		args = append(args, "--ignore-personal")
+	}
+	if w.IgnoreGroup {
+		args = append(args, "--ignore-group")
+	}
+	if w.ShowWildcard {
+		args = append(args, "--show-wildcard")
+	}
+	return args
+}
+
+// WhoHasAccessTo lists the accounts having access to a host or subnet, either personally or through a group.
+// The result is sorted by account, access type and group.
+func (c *Client) WhoHasAccessTo(host string, options *WhoHasAccessToOptions) ([]*WhoHasAccessTo, error) {
+	args := []string{"--host", host}
+	if options != nil {
+		args = append(args, options.toArgs()...)
+	}
+	response, err := c.executeCommand("whoHasAccessTo", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	valueBytes, err := json.Marshal(response.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	var accesses []*WhoHasAccessTo
+	if err := json.Unmarshal(valueBytes, &accesses); err != nil {
+		return nil, err
+	}
+
+	group := func(w *WhoHasAccessTo) string {
+		if w.Group == nil {
+			return ""
+		}
+		return *w.Group
+	}
+	slices.SortFunc(accesses, func(a, b *WhoHasAccessTo) int {
+		return cmp.Or(
+			cmp.Compare(a.Account, b.Account),
+			cmp.Compare(a.AccessType, b.AccessType),
+			cmp.Compare(group(a), group(b)),
+		)
+	})
+
+	return accesses, nil
+}
@@ -4,11 +4,16 @@
 package bastion
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 )
 
+// errorCodeGroupNotFound is the error code returned by commands referencing a group which does not exist.
+const errorCodeGroupNotFound = "KO_GROUP_NOT_FOUND"
+
 // APIResponse represents the standard API response from The Bastion.
 type APIResponse struct {
 	Command      string `json:"command"`
@@ -22,8 +27,19 @@ func (e *APIResponse) Error() string {
 	return fmt.Sprintf("Bastion API error [%s]: %s (command: %s)", e.ErrorCode, e.ErrorMessage, e.Command)
 }
 
+// IsGroupNotFound checks if the error reports that the group does not exist.
+func IsGroupNotFound(err error) bool {
+	var response *APIResponse
+	return errors.As(err, &response) && response.ErrorCode == errorCodeGroupNotFound
+}
+
 // executeCommand executes a command on The Bastion and returns the JSON response.
 func (c *Client) executeCommand(command string, args ...string) (*APIResponse, error) {
+	return c.executeCommandWithInput(command, nil, args...)
+}
+
+// executeCommandWithInput executes a command on The Bastion with the given input on stdin and returns the JSON response.
+func (c *Client) executeCommandWithInput(command string, input []byte, args ...string) (*APIResponse, error) {
 	sshClient, err := c.sshClient()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create SSH client: %w", err)
@@ -38,6 +54,10 @@ func (c *Client) executeCommand(command string, args ...string) (*APIResponse, e
 	}
 	defer session.Close() //nolint:errcheck
 
+	if input != nil {
+		session.Stdin = bytes.NewReader(input)
+	}
+
 	output, err := session.CombinedOutput(fullCommand)
 	response, parseErr := parseJSONGreppableOutput(string(output))
 	if parseErr != nil {
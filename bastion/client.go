@@ -74,6 +74,11 @@ func New(cfg *Config, authMethods ...SSHAuthMethod) (*Client, error) {
 	}, nil
 }
 
+// Username returns the account the client runs the commands as.
+func (c *Client) Username() string {
+	return c.sshClientCfg.User
+}
+
 // validateConfig checks that the provided configuration is valid.
 func validateConfig(cfg *Config) error {
 	if cfg == nil {
@@ -0,0 +1,102 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package bastion
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Duration represents a duration in seconds, as used by The Bastion for TTLs and timeouts.
+type Duration int64
+
+// durationUnits are the units of a duration string in seconds, largest first.
+var durationUnits = []struct {
+	suffix  string
+	seconds int64
+}{
+	{"w", 7 * 24 * 3600},
+	{"d", 24 * 3600},
+	{"h", 3600},
+	{"m", 60},
+	{"s", 1},
+}
+
+// durationPattern matches duration strings like 1d2h, 90m or 3600s.
+var durationPattern = regexp.MustCompile(`^(?:\d+[wdhms])+$`)
+
+// durationPartPattern matches a single number and unit of a duration string.
+var durationPartPattern = regexp.MustCompile(`(\d+)([wdhms])`)
+
+// ParseDuration parses a duration as accepted by The Bastion.
+// Both a number of seconds like 3600 and a duration string like 1d2h are accepted.
+func ParseDuration(value string) (Duration, error) {
+	value = strings.TrimSpace(value)
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return Duration(seconds), nil
+	}
+
+	if !durationPattern.MatchString(value) {
+		return 0, fmt.Errorf("invalid duration %q, expected a number of seconds or a duration like 1d2h30m", value)
+	}
+
+	var seconds int64
+	for _, part := range durationPartPattern.FindAllStringSubmatch(value, -1) {
+		n, err := strconv.ParseInt(part[1], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %w", value, err)
+		}
+		for _, unit := range durationUnits {
+			if unit.suffix == part[2] {
+				seconds += n * unit.seconds
+			}
+		}
+	}
+
+	return Duration(seconds), nil
+}
+
+// Seconds returns the duration as a number of seconds.
+func (d Duration) Seconds() int64 {
+	return int64(d)
+}
+
+// String formats the duration like The Bastion does, e.g. 1d2h30m.
+// Durations of zero or less are special values for The Bastion and are formatted as a plain number.
+func (d Duration) String() string {
+	if d <= 0 {
+		return strconv.FormatInt(int64(d), 10)
+	}
+
+	var b strings.Builder
+	remaining := int64(d)
+	// weeks are not used by The Bastion when reporting durations
+	for _, unit := range durationUnits[1:] {
+		if remaining >= unit.seconds {
+			fmt.Fprintf(&b, "%d%s", remaining/unit.seconds, unit.suffix)
+			remaining %= unit.seconds
+		}
+	}
+
+	return b.String()
+}
+
+// UnmarshalJSON decodes a duration reported by The Bastion either as a number or as a string.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	raw := strings.Trim(string(data), `"`)
+	if raw == "" || raw == "null" {
+		*d = 0
+		return nil
+	}
+
+	value, err := ParseDuration(raw)
+	if err != nil {
+		return err
+	}
+	*d = value
+	return nil
+}
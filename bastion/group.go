@@ -6,6 +6,8 @@ package bastion
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 )
 
 // Group represents a Bastion group.
@@ -20,9 +22,9 @@ type Group struct {
 	GuestAccesses   []string           `json:"guest_accesses"`
 	Keys            map[string]Key     `json:"keys"`
 	MFARequired     *MFARequiredPolicy `json:"mfa_required"`
-	IdleLockTimeout *string            `json:"idle_lock_timeout"`
-	IdleKillTimeout *string            `json:"idle_kill_timeout"`
-	GuestTtlLimit   *string            `json:"guest_ttl_limit"`
+	IdleLockTimeout *Duration          `json:"idle_lock_timeout"`
+	IdleKillTimeout *Duration          `json:"idle_kill_timeout"`
+	GuestTtlLimit   *Duration          `json:"guest_ttl_limit"`
 	TryPersonalKeys *BoolFromInt       `json:"try_personal_keys"`
 }
 
@@ -46,6 +48,87 @@ func (c *Client) GroupInfo(name string) (*Group, error) {
 	return &group, nil
 }
 
+// SortedKeys returns the egress keys of the group, oldest first.
+func (g *Group) SortedKeys() []Key {
+	keys := make([]Key, 0, len(g.Keys))
+	for _, key := range g.Keys {
+		keys = append(keys, key)
+	}
+	sortKeys(keys)
+	return keys
+}
+
+// GroupListItem represents a Bastion group as listed by GroupList.
+type GroupListItem struct {
+	Group string `json:"group"`
+	// Flags holds the roles of the calling account in the group (owner, gatekeeper, aclkeeper, member, guest).
+	Flags []string `json:"flags"`
+}
+
+// GroupListOptions holds options for listing Bastion groups.
+type GroupListOptions struct {
+	// All lists every group, not only the ones the calling account has a role in.
+	All bool
+	// Include only lists the groups matching one of these shell-like patterns.
+	Include []string
+	// Exclude omits the groups matching one of these shell-like patterns.
+	Exclude []string
+}
+
+func (g *GroupListOptions) toArgs() []string {
+	args := []string{}
+	if g.All {
+		args = append(args, "--all")
+	}
+	for _, pattern := range g.Include {
+		args = append(args, "--include", fmt.Sprintf("%q", pattern))
+	}
+	for _, pattern := range g.Exclude {
+		args = append(args, "--exclude", fmt.Sprintf("%q", pattern))
+	}
+	return args
+}
+
+// GroupList lists the Bastion groups, sorted by name.
+func (c *Client) GroupList(listOpts *GroupListOptions) ([]*GroupListItem, error) {
+	args := []string{}
+	if listOpts != nil {
+		args = append(args, listOpts.toArgs()...)
+	}
+
+	response, err := c.executeCommand("groupList", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	valueBytes, err := json.Marshal(response.Value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response value: %w", err)
+	}
+
+	// the groups are returned as a map indexed by group name
+	var groupMap map[string]*GroupListItem
+	if err := json.Unmarshal(valueBytes, &groupMap); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal group list: %w", err)
+	}
+
+	groups := make([]*GroupListItem, 0, len(groupMap))
+	for name, group := range groupMap {
+		if group == nil {
+			group = &GroupListItem{}
+		}
+		if group.Group == "" {
+			group.Group = name
+		}
+		groups = append(groups, group)
+	}
+	slices.SortFunc(groups, func(a, b *GroupListItem) int {
+		return strings.Compare(a.Group, b.Group)
+	})
+
+	return groups, nil
+}
+
 // CreateGroup creates a new Bastion group.
 func (c *Client) CreateGroup(name, owner string, keyAlgo KeyAlgo) (*Group, error) {
 	algo, size := keyAlgo.AlgoAndSize()
@@ -70,9 +153,9 @@ func (c *Client) CreateGroup(name, owner string, keyAlgo KeyAlgo) (*Group, error
 // GroupModifyOptions holds options for modifying a Bastion group.
 type GroupModifyOptions struct {
 	MFARequired     *MFARequiredPolicy
-	IdleLockTimeout *string
-	IdleKillTimeout *string
-	GuestTtlLimit   *string
+	IdleLockTimeout *Duration
+	IdleKillTimeout *Duration
+	GuestTtlLimit   *Duration
 	TryPersonalKeys *bool
 }
 
@@ -82,13 +165,13 @@ func (g *GroupModifyOptions) toArgs() []string {
 		args = append(args, "--mfa-required", string(*g.MFARequired))
 	}
 	if g.IdleLockTimeout != nil {
-		args = append(args, "--idle-lock-timeout", *g.IdleLockTimeout)
+		args = append(args, "--idle-lock-timeout", g.IdleLockTimeout.String())
 	}
 	if g.IdleKillTimeout != nil {
-		args = append(args, "--idle-kill-timeout", *g.IdleKillTimeout)
+		args = append(args, "--idle-kill-timeout", g.IdleKillTimeout.String())
 	}
 	if g.GuestTtlLimit != nil {
-		args = append(args, "--guest-ttl-limit", *g.GuestTtlLimit)
+		args = append(args, "--guest-ttl-limit", g.GuestTtlLimit.String())
 	}
 	if g.TryPersonalKeys != nil {
 		if *g.TryPersonalKeys {
@@ -178,3 +261,30 @@ func (c *Client) GroupTransmitOwnership(group, account string) error {
 	_, err := c.executeCommand("groupTransmitOwnership", "--group", group, "--account", account)
 	return err
 }
+
+// GroupGenerateEgressKey generates an additional egress key for a Bastion group.
+func (c *Client) GroupGenerateEgressKey(group string, keyAlgo KeyAlgo) (*Key, error) {
+	algo, size := keyAlgo.AlgoAndSize()
+	response, err := c.executeCommand("groupGenerateEgressKey", "--group", group, "--algo", algo, "--size", fmt.Sprintf("%d", size))
+	if err != nil {
+		return nil, err
+	}
+
+	valueBytes, err := json.Marshal(response.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	var key Key
+	if err := json.Unmarshal(valueBytes, &key); err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+// GroupDelEgressKey deletes an egress key of a Bastion group, identified by the ID listed in GroupInfo.
+func (c *Client) GroupDelEgressKey(group, id string) error {
+	_, err := c.executeCommand("groupDelEgressKey", "--group", group, "--id", id)
+	return err
+}
@@ -33,7 +33,7 @@ func (c *Client) GroupListGuestAccesses(group, account string) ([]*GroupGuestAcc
 
 // GroupAddGuestAccessOptions represents options for adding a guest access to a group.
 type GroupAddGuestAccessOptions struct {
-	TTL          string
+	TTL          Duration
 	Comment      string
 	Protocol     string
 	ProxyOptions *ProxyOptions
@@ -51,8 +51,8 @@ func (g *GroupAddGuestAccessOptions) validate() error {
 
 func (g *GroupAddGuestAccessOptions) toArgs() []string {
 	args := []string{}
-	if g.TTL != "" {
-		args = append(args, "--ttl", g.TTL)
+	if g.TTL != 0 {
+		args = append(args, "--ttl", g.TTL.String())
 	}
 	if g.Comment != "" {
 		args = append(args, "--comment", fmt.Sprintf("%q", g.Comment))
@@ -36,7 +36,7 @@ type GroupAddServerOptions struct {
 	Force         bool
 	ForceKey      string
 	ForcePassword string
-	TTL           string
+	TTL           Duration
 	Comment       string
 	Protocol      string
 	ProxyOptions  *ProxyOptions
@@ -91,8 +91,8 @@ func (g *GroupAddServerOptions) toArgs() []string {
 	if g.ForcePassword != "" {
 		args = append(args, "--force-password", g.ForcePassword)
 	}
-	if g.TTL != "" {
-		args = append(args, "--ttl", g.TTL)
+	if g.TTL != 0 {
+		args = append(args, "--ttl", g.TTL.String())
 	}
 	if g.Comment != "" {
 		args = append(args, "--comment", fmt.Sprintf("%q", g.Comment))
@@ -159,3 +159,34 @@ func (c *Client) GroupDelServer(group, host, port, user, protocol string, proxyO
 	_, err := c.executeCommand("groupDelServer", args...)
 	return err
 }
+
+// GroupSetServersEntry represents a server access of a group, as passed to GroupSetServers.
+type GroupSetServersEntry struct {
+	IP string `json:"ip"`
+	// Port is nil to allow all ports.
+	Port *int `json:"port"`
+	// User is nil to allow all users, protocol accesses use the "!protocol" form.
+	User          *string `json:"user"`
+	ProxyIP       *string `json:"proxyIp,omitempty"`
+	ProxyPort     *int    `json:"proxyPort,omitempty"`
+	ProxyUser     *string `json:"proxyUser,omitempty"`
+	Comment       *string `json:"comment,omitempty"`
+	ForceKey      *string `json:"forceKey,omitempty"`
+	ForcePassword *string `json:"forcePassword,omitempty"`
+	RemotePort    *int    `json:"remotePort,omitempty"`
+}
+
+// GroupSetServers atomically replaces all server accesses of a group.
+func (c *Client) GroupSetServers(group string, servers []GroupSetServersEntry) error {
+	if servers == nil {
+		servers = []GroupSetServersEntry{}
+	}
+
+	input, err := json.Marshal(servers)
+	if err != nil {
+		return fmt.Errorf("failed to marshal servers: %w", err)
+	}
+
+	_, err = c.executeCommandWithInput("groupSetServers", input, "--group", group)
+	return err
+}
@@ -0,0 +1,83 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package bastion
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// CanonicalIP returns the canonical form of an IP address or subnet as stored by The Bastion.
+// Host prefixes like 10.0.0.1/32 are reduced to the address, subnets are masked to their network address,
+// IPv4 octets with leading zeros like 10.00.0.1 are accepted and IPv6 addresses are compressed.
+func CanonicalIP(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	addrPart, bitsPart, isPrefix := strings.Cut(value, "/")
+
+	addr, err := parseAddr(addrPart)
+	if err != nil {
+		return "", fmt.Errorf("invalid IP address or subnet %q", value)
+	}
+
+	if !isPrefix {
+		return addr.String(), nil
+	}
+
+	bits, err := strconv.Atoi(bitsPart)
+	if err != nil {
+		return "", fmt.Errorf("invalid prefix length in subnet %q", value)
+	}
+	prefix, err := addr.Prefix(bits)
+	if err != nil {
+		return "", fmt.Errorf("invalid prefix length in subnet %q", value)
+	}
+
+	if bits == addr.BitLen() {
+		return addr.String(), nil
+	}
+	return prefix.String(), nil
+}
+
+// EqualIP checks if two IP addresses or subnets designate the same target.
+// Values which cannot be parsed are compared as is.
+func EqualIP(a, b string) bool {
+	canonicalA, errA := CanonicalIP(a)
+	canonicalB, errB := CanonicalIP(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return canonicalA == canonicalB
+}
+
+// parseAddr parses an IP address without zone, also accepting IPv4 octets with leading zeros.
+func parseAddr(value string) (netip.Addr, error) {
+	addr, err := netip.ParseAddr(value)
+	if err == nil {
+		if addr.Zone() != "" {
+			return netip.Addr{}, fmt.Errorf("zones are not supported")
+		}
+		return addr, nil
+	}
+
+	// netip rejects leading zeros as they are ambiguous, The Bastion reads them as decimal
+	octets := strings.Split(value, ".")
+	if len(octets) != 4 {
+		return netip.Addr{}, err
+	}
+
+	var ip [4]byte
+	for i, octet := range octets {
+		if octet == "" || strings.TrimLeft(octet, "0123456789") != "" {
+			return netip.Addr{}, err
+		}
+		n, convErr := strconv.ParseUint(octet, 10, 8)
+		if convErr != nil {
+			return netip.Addr{}, err
+		}
+		ip[i] = byte(n)
+	}
+	return netip.AddrFrom4(ip), nil
+}
@@ -3,6 +3,13 @@
 
 package bastion
 
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
 // Key represents a Bastion SSH key.
 type Key struct {
 	Prefix      string   `json:"prefix"`
@@ -20,6 +27,13 @@ type Key struct {
 	Line        string   `json:"line"`
 }
 
+// sortKeys sorts keys by modification time, oldest first, falling back to the fingerprint.
+func sortKeys(keys []Key) {
+	slices.SortFunc(keys, func(a, b Key) int {
+		return cmp.Or(cmp.Compare(a.Mtime, b.Mtime), cmp.Compare(a.Fingerprint, b.Fingerprint))
+	})
+}
+
 // KeyAlgo represents a Bastion SSH key algorithm.
 type KeyAlgo string
 
@@ -53,3 +67,23 @@ func (k KeyAlgo) AlgoAndSize() (string, int) {
 		return "", 0
 	}
 }
+
+// KeyAlgoFromFamily returns the KeyAlgo matching a key family and size, as listed in Key.
+func KeyAlgoFromFamily(family string, size int) KeyAlgo {
+	for _, algo := range []KeyAlgo{ED25519, RSA2048, RSA4096, RSA8192, ECDSA256, ECDSA384, ECDSA521} {
+		name, algoSize := algo.AlgoAndSize()
+		if name == strings.ToLower(family) && (algoSize == 0 || algoSize == size) {
+			return algo
+		}
+	}
+	return ""
+}
+
+// AuthorizedKeysLine returns the public key line for an authorized_keys file,
+// restricted with a from="..." option to the IPs listed in FromList.
+func (k Key) AuthorizedKeysLine() string {
+	if len(k.FromList) == 0 {
+		return k.Line
+	}
+	return fmt.Sprintf("from=%q %s", strings.Join(k.FromList, ","), k.Line)
+}
@@ -0,0 +1,83 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package bastion
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// EgressPassword represents an egress password of a Bastion group or account.
+// The Bastion only ever exposes the hashes of egress passwords, never their plaintext.
+type EgressPassword struct {
+	ID          int               `json:"id"`
+	Description string            `json:"description"`
+	Hashes      map[string]string `json:"hashes"`
+}
+
+// GroupGeneratePassword generates a new egress password for a group.
+// The previous password is kept as a fallback. A size of 0 uses the default size.
+func (c *Client) GroupGeneratePassword(group string, size int) (*EgressPassword, error) {
+	args := []string{"--group", group, "--do-it"}
+	if size != 0 {
+		args = append(args, "--size", fmt.Sprintf("%d", size))
+	}
+	return c.generatePassword("groupGeneratePassword", args)
+}
+
+// GroupListPasswords lists the egress passwords of a group, the current one first.
+func (c *Client) GroupListPasswords(group string) ([]EgressPassword, error) {
+	return c.listPasswords("groupListPasswords", "--group", group)
+}
+
+// AccountGeneratePassword generates a new egress password for an account.
+// The previous password is kept as a fallback. A size of 0 uses the default size.
+func (c *Client) AccountGeneratePassword(account string, size int) (*EgressPassword, error) {
+	args := []string{"--account", account, "--do-it"}
+	if size != 0 {
+		args = append(args, "--size", fmt.Sprintf("%d", size))
+	}
+	return c.generatePassword("accountGeneratePassword", args)
+}
+
+// AccountListPasswords lists the egress passwords of an account, the current one first.
+func (c *Client) AccountListPasswords(account string) ([]EgressPassword, error) {
+	return c.listPasswords("accountListPasswords", "--account", account)
+}
+
+func (c *Client) generatePassword(command string, args []string) (*EgressPassword, error) {
+	response, err := c.executeCommand(command, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	valueBytes, err := json.Marshal(response.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	var password EgressPassword
+	if err := json.Unmarshal(valueBytes, &password); err != nil {
+		return nil, err
+	}
+	return &password, nil
+}
+
+func (c *Client) listPasswords(command string, args ...string) ([]EgressPassword, error) {
+	response, err := c.executeCommand(command, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	valueBytes, err := json.Marshal(response.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	var passwords []EgressPassword
+	if err := json.Unmarshal(valueBytes, &passwords); err != nil {
+		return nil, err
+	}
+	return passwords, nil
+}
@@ -0,0 +1,10 @@
+data "bastion_account" "example" {
+  account = "kal-el"
+}
+
+# only grant access to accounts which can actually connect
+resource "bastion_group_member" "example" {
+  count   = data.bastion_account.example.can_connect ? 1 : 0
+  group   = "kryptonians"
+  account = data.bastion_account.example.account
+}
@@ -0,0 +1,16 @@
+# all accesses of an account
+data "bastion_account_accesses" "example" {
+  account = "kal-el"
+}
+
+# accesses to a single server granted through a group
+data "bastion_account_accesses" "fortress" {
+  account = "kal-el"
+  type    = "group"
+  group   = "kryptonians"
+  ip      = "192.168.1.100"
+}
+
+output "fortress_accesses" {
+  value = [for a in data.bastion_account_accesses.fortress.accesses : "${a.user}@${a.ip}:${a.port}"]
+}
@@ -0,0 +1,8 @@
+data "bastion_account_egress_keys" "example" {
+  account = "kal-el"
+}
+
+# authorize the account's egress keys on a personal server
+output "authorized_keys" {
+  value = join("\n", data.bastion_account_egress_keys.example.keys[*].line)
+}
@@ -0,0 +1,17 @@
+# all active accounts starting with "kal-"
+data "bastion_accounts" "example" {
+  name_prefix = "kal-"
+  is_active   = true
+}
+
+# all frozen members of a group
+data "bastion_accounts" "frozen_kryptonians" {
+  group     = "kryptonians"
+  is_frozen = true
+}
+
+resource "bastion_account_command" "example" {
+  for_each = toset(data.bastion_accounts.example.names)
+  account  = each.value
+  command  = "selfAddPersonalAccess"
+}
@@ -1,4 +1,14 @@
-
 data "bastion_group" "example" {
   group = "humans"
 }
+
+# also list the server accesses of the group and of its guests
+data "bastion_group" "kryptonians" {
+  group                  = "kryptonians"
+  include_servers        = true
+  include_guest_accesses = true
+}
+
+output "kryptonian_servers" {
+  value = distinct(data.bastion_group.kryptonians.servers[*].ip)
+}
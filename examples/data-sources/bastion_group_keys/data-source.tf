@@ -0,0 +1,8 @@
+data "bastion_group_keys" "example" {
+  group = "kryptonians"
+}
+
+# only accept the group keys when connecting from The Bastion
+output "authorized_keys" {
+  value = data.bastion_group_keys.example.authorized_keys
+}
@@ -0,0 +1,22 @@
+data "bastion_group_servers" "example" {
+  group     = "kryptonians"
+  ip_prefix = "192.168.1.0/24"
+  protocol  = "ssh"
+}
+
+# bring the existing accesses under Terraform management
+import {
+  for_each = { for s in data.bastion_group_servers.example.servers : s.import_id => s }
+  to       = bastion_group_server.imported[each.key]
+  id       = each.key
+}
+
+resource "bastion_group_server" "imported" {
+  for_each = { for s in data.bastion_group_servers.example.servers : s.import_id => s }
+
+  group   = "kryptonians"
+  ip      = each.value.ip
+  port    = each.value.port
+  user    = each.value.user
+  comment = each.value.comment
+}
@@ -0,0 +1,15 @@
+# every team group kal-el is a member of
+data "bastion_groups" "example" {
+  name_prefix = "team-"
+  member      = "kal-el"
+}
+
+# the groups the provider account owns, with their settings
+data "bastion_groups" "owned" {
+  role         = "owner"
+  include_info = true
+}
+
+output "groups_without_mfa" {
+  value = [for g in data.bastion_groups.owned.groups : g.group if g.info.mfa_required == null]
+}
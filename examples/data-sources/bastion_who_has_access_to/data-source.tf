@@ -0,0 +1,12 @@
+data "bastion_who_has_access_to" "example" {
+  ip   = "192.168.1.100"
+  port = "22"
+}
+
+# make sure nobody can reach the server anymore before decommissioning it
+check "fortress_decommissioned" {
+  assert {
+    condition     = length(data.bastion_who_has_access_to.example.names) == 0
+    error_message = "Accounts still have access: ${join(", ", data.bastion_who_has_access_to.example.names)}"
+  }
+}
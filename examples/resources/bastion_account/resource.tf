@@ -5,4 +5,24 @@ resource "bastion_account" "example" {
   public_key        = file("id_ed25519.pub")
   max_inactive_days = 90
   mfa_totp_required = "yes"
-}
\ No newline at end of file
+
+  # reactivate the account on apply if it expired because of inactivity
+  auto_unexpire = true
+}
+
+# freeze an account during an incident without deleting it
+resource "bastion_account" "frozen" {
+  account       = "jor-el"
+  uid_auto      = true
+  no_key        = true
+  frozen        = true
+  freeze_reason = "INC-1234: suspected key compromise"
+}
+
+# temporary account for a contractor, valid until the end of the change window
+resource "bastion_account" "temporary" {
+  account    = "kara-zor-el"
+  uid_auto   = true
+  public_key = file("kara_ed25519.pub")
+  expires_at = "2026-11-01T18:00:00Z"
+}
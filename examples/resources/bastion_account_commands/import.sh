@@ -0,0 +1,2 @@
+# Account command sets can be imported using the account name
+terraform import bastion_account_commands.example kal-el
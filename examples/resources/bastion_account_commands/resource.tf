@@ -0,0 +1,10 @@
+resource "bastion_account_commands" "example" {
+  account = "kal-el"
+  commands = [
+    "selfAddPersonalAccess",
+    "selfDelPersonalAccess",
+  ]
+
+  # never revoke the admin access of the account, even if granted out of band
+  protected_commands = ["adminSudo"]
+}
@@ -0,0 +1,2 @@
+# The current egress password of an account can be imported using the account name
+terraform import bastion_account_egress_password.example kal-el
@@ -0,0 +1,8 @@
+resource "bastion_account_egress_password" "example" {
+  account          = "kal-el"
+  rotation_trigger = "2026-Q4"
+}
+
+output "kal_el_password_hash" {
+  value = bastion_account_egress_password.example.hashes["sha512crypt"]
+}
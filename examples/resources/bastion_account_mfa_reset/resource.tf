@@ -0,0 +1,6 @@
+# kal-el lost their TOTP device, bump the trigger to reset it again later
+resource "bastion_account_mfa_reset" "example" {
+  account       = "kal-el"
+  totp          = true
+  reset_trigger = "INC-4242"
+}
@@ -0,0 +1,14 @@
+
+# Expected formats:
+# - account:ip:port:user
+# - account:ip:port:user:protocol
+# - account:ip:port:user:protocol:remote_port (when protocol is "portforward")
+# - account:ip:port:user:proxy_ip:proxy_port:proxy_user
+# - account:ip:port:user:protocol:proxy_ip:proxy_port:proxy_user
+# - account:ip:port:user:protocol:remote_port:proxy_ip:proxy_port:proxy_user (when protocol is "portforward")
+
+terraform import bastion_account_personal_access.example 'kal-el:192.168.1.100:22:kal-el'
+
+terraform import bastion_account_personal_access.example2 'kal-el:[2001:db8::1]:22:kal-el'
+
+terraform import bastion_account_personal_access.example3 'kal-el:192.168.1.100:22::sftp'
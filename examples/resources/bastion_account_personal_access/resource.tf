@@ -0,0 +1,18 @@
+# basic example
+resource "bastion_account_personal_access" "example" {
+  account = "kal-el"
+  ip      = "192.168.1.100"
+  port    = "22"
+  user    = "kal-el"
+  comment = "fortress of solitude"
+}
+
+# example with protocol access.
+# in order to create a protocol access, a base personal access must first exist.
+resource "bastion_account_personal_access" "example_sftp" {
+  account    = "kal-el"
+  ip         = "192.168.1.100"
+  port       = "22"
+  protocol   = "sftp"
+  depends_on = [bastion_account_personal_access.example]
+}
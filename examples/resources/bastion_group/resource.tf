@@ -8,3 +8,11 @@ resource "bastion_group" "example" {
   idle_kill_timeout = "6h"
   guest_ttl_limit   = "7d"
 }
+
+# Group owned by another account, the provider account is an admin and not an owner,
+# so ownership is changed by adding the new owner and removing the old one
+resource "bastion_group" "daily_planet" {
+  group                 = "daily-planet"
+  owner                 = "perry-white"
+  owner_change_strategy = "add_then_remove"
+}
@@ -0,0 +1,7 @@
+resource "bastion_group_aclkeepers" "example" {
+  group    = "kryptonians"
+  accounts = ["jor-el"]
+
+  # never touch the break-glass account
+  excluded_accounts = ["bastionadmin"]
+}
@@ -0,0 +1,2 @@
+# Group egress keys can be imported using the group name and the key fingerprint separated by a colon
+terraform import bastion_group_egress_key.example "kryptonians:SHA256:Yb0j3Xv8rAfGhS4mmxJq5b9HkVQ2sE1nNf9cZuA0tLw"
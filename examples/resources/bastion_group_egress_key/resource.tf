@@ -0,0 +1,13 @@
+# add a second key to the group, deploy it to the servers, then remove the old one
+resource "bastion_group_egress_key" "example" {
+  group = "kryptonians"
+  algo  = "ed25519"
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+
+output "kryptonians_egress_key" {
+  value = bastion_group_egress_key.example.line
+}
@@ -0,0 +1,2 @@
+# The current egress password of a group can be imported using the group name
+terraform import bastion_group_egress_password.example kryptonians
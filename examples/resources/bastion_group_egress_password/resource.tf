@@ -0,0 +1,14 @@
+resource "bastion_group_egress_password" "example" {
+  group            = "kryptonians"
+  size             = 32
+  rotation_trigger = "2026-Q4"
+}
+
+# only use the generated password to connect to the legacy server
+resource "bastion_group_server" "legacy" {
+  group          = "kryptonians"
+  ip             = "192.168.1.150"
+  port           = "22"
+  user           = "jor-el"
+  force_password = bastion_group_egress_password.example.hashes["sha512crypt"]
+}
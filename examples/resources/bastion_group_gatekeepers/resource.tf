@@ -0,0 +1,7 @@
+resource "bastion_group_gatekeepers" "example" {
+  group    = "kryptonians"
+  accounts = ["jor-el", "lara"]
+
+  # never touch the break-glass account
+  excluded_accounts = ["bastionadmin"]
+}
@@ -6,3 +6,34 @@ resource "bastion_group_guest_access" "guest_db_access" {
   port    = "22"
   user    = "*"
 }
+
+# Temporary guest access, the plan fails once it expired until it is removed
+resource "bastion_group_guest_access" "guest_temporary_access" {
+  group     = "kryptonians"
+  account   = "jonnjonzz"
+  ip        = "192.168.1.101"
+  port      = "22"
+  user      = "root"
+  ttl       = "1h"
+  on_expiry = "error"
+}
+
+# Guest access granted until the end of a change window
+resource "bastion_group_guest_access" "guest_change_window" {
+  group      = "kryptonians"
+  account    = "jonnjonzz"
+  ip         = "192.168.1.102"
+  port       = "22"
+  user       = "root"
+  expires_at = "2026-11-01T18:00:00+01:00"
+  on_expiry  = "keep_absent"
+}
+
+# Guest access to every address the hostname resolves to
+resource "bastion_group_guest_access" "guest_hostname_access" {
+  group    = "kryptonians"
+  account  = "jonnjonzz"
+  hostname = "watchtower.example.com"
+  port     = "22"
+  user     = "root"
+}
@@ -0,0 +1,2 @@
+
+terraform import bastion_group_members.example kryptonians
@@ -0,0 +1,7 @@
+resource "bastion_group_members" "example" {
+  group    = "kryptonians"
+  accounts = ["kal-el", "kara-zor-el"]
+
+  # never touch the break-glass account
+  excluded_accounts = ["bastionadmin"]
+}
@@ -6,6 +6,14 @@ resource "bastion_group_server" "example" {
   user  = "kal-el"
 }
 
+# example with a hostname, one access is granted per resolved address
+resource "bastion_group_server" "example_hostname" {
+  group    = "kryptonians"
+  hostname = "fortress.example.com"
+  port     = "22"
+  user     = "kal-el"
+}
+
 # example with an ssh proxyjump
 resource "bastion_group_server" "example_proxy" {
   group      = "kryptonians"
@@ -57,4 +65,13 @@ resource "bastion_group_server" "example_portforward" {
   protocol    = "portforward"
   remote_port = 8080
   depends_on  = [bastion_group_server.example_base]
-}
\ No newline at end of file
+}
+# Temporary access which is not added again once it expired
+resource "bastion_group_server" "example_temporary" {
+  group     = "kryptonians"
+  ip        = "192.168.1.210"
+  port      = "22"
+  user      = "root"
+  ttl       = "1d"
+  on_expiry = "keep_absent"
+}
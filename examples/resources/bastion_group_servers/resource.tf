@@ -0,0 +1,24 @@
+locals {
+  fortress_hosts = ["192.168.1.150", "192.168.1.151", "192.168.1.152"]
+}
+
+resource "bastion_group_servers" "example" {
+  group = "kryptonians"
+
+  servers = concat(
+    [for ip in local.fortress_hosts : {
+      ip       = ip
+      port     = "22"
+      user     = "jor-el"
+      protocol = null
+      comment  = "fortress of solitude"
+    }],
+    [{
+      ip       = "192.168.1.150"
+      port     = "22"
+      user     = null
+      protocol = "sftp"
+      comment  = null
+    }],
+  )
+}
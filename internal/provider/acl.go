@@ -0,0 +1,263 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/adfinis/terraform-provider-bastion/internal/provider/customtypes"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// accessACL points to the attributes an access resource shares with the ACL entries of The Bastion, so that
+// personal, group server and guest accesses are matched, refreshed and imported the same way.
+type accessACL struct {
+	IP         *customtypes.IPAddress
+	Port       *types.String
+	User       *types.String
+	Protocol   *types.String
+	ProxyIP    *customtypes.IPAddress
+	ProxyPort  *types.String
+	ProxyUser  *types.String
+	RemotePort *types.Int64
+	Comment    *types.String
+}
+
+// matchesACL checks if an ACL entry matches the access.
+func matchesACL(access accessACL, acl *bastion.ACL) bool {
+	if !bastion.EqualIP(access.IP.ValueString(), acl.IP) {
+		return false
+	}
+
+	// Check port (API returns null for "*")
+	aclPort := "*"
+	if acl.Port != nil {
+		aclPort = acl.Port.ValueString()
+	}
+	if access.Port.ValueString() != aclPort {
+		return false
+	}
+
+	// Check user (API returns null for "*")
+	// For protocol accesses, API returns username as "!protocol"
+	aclUser := "*"
+	if acl.User != nil {
+		aclUser = *acl.User
+	}
+
+	if !access.Protocol.IsNull() && access.Protocol.ValueString() != "" {
+		if aclUser != "!"+access.Protocol.ValueString() {
+			return false
+		}
+	} else if access.User.ValueString() != aclUser {
+		return false
+	}
+
+	// Check remote_port (for portforward protocol)
+	accessHasRemotePort := !access.RemotePort.IsNull()
+	aclHasRemotePort := acl.RemotePort != nil
+
+	if accessHasRemotePort != aclHasRemotePort {
+		return false
+	}
+
+	if accessHasRemotePort && access.RemotePort.ValueInt64() != int64(acl.RemotePort.ValueInt()) {
+		return false
+	}
+
+	// Check proxy settings
+	accessHasProxy := !access.ProxyIP.IsNull()
+	aclHasProxy := acl.ProxyIP != nil
+
+	if accessHasProxy != aclHasProxy {
+		return false
+	}
+
+	if accessHasProxy {
+		if !bastion.EqualIP(access.ProxyIP.ValueString(), *acl.ProxyIP) {
+			return false
+		}
+
+		// Check proxy port (API returns null for "*")
+		aclProxyPort := "*"
+		if acl.ProxyPort != nil {
+			aclProxyPort = acl.ProxyPort.ValueString()
+		}
+		if access.ProxyPort.ValueString() != aclProxyPort {
+			return false
+		}
+
+		if acl.ProxyUser == nil || access.ProxyUser.ValueString() != *acl.ProxyUser {
+			return false
+		}
+	}
+
+	return true
+}
+
+// flattenACL updates the access from an ACL entry. The IP is left to the caller, as accesses granted through a
+// hostname keep it null.
+func flattenACL(access accessACL, acl *bastion.ACL) {
+	if acl.Port != nil {
+		*access.Port = types.StringValue(acl.Port.ValueString())
+	} else {
+		*access.Port = types.StringValue("*")
+	}
+
+	// Handle protocol
+	if acl.User != nil && strings.HasPrefix(*acl.User, "!") {
+		// This is a protocol access
+		*access.Protocol = types.StringValue(strings.TrimPrefix(*acl.User, "!"))
+		*access.User = types.StringNull()
+	} else if acl.User != nil {
+		*access.User = types.StringValue(*acl.User)
+		*access.Protocol = types.StringNull()
+	} else {
+		*access.User = types.StringValue("*")
+		*access.Protocol = types.StringNull()
+	}
+
+	if acl.ProxyIP != nil {
+		*access.ProxyIP = customtypes.NewIPAddressValue(*acl.ProxyIP)
+	} else {
+		*access.ProxyIP = customtypes.NewIPAddressNull()
+	}
+	if acl.ProxyPort != nil {
+		*access.ProxyPort = types.StringValue(acl.ProxyPort.ValueString())
+	} else if !access.ProxyPort.IsNull() && access.ProxyPort.ValueString() == "*" {
+		*access.ProxyPort = types.StringValue("*")
+	} else {
+		*access.ProxyPort = types.StringNull()
+	}
+	if acl.ProxyUser != nil {
+		*access.ProxyUser = types.StringValue(*acl.ProxyUser)
+	} else {
+		*access.ProxyUser = types.StringNull()
+	}
+
+	if acl.RemotePort != nil {
+		*access.RemotePort = types.Int64Value(int64(acl.RemotePort.ValueInt()))
+	} else {
+		*access.RemotePort = types.Int64Null()
+	}
+
+	// Listed accesses report the comment as userComment, added ones as comment
+	switch {
+	case acl.UserComment != nil:
+		*access.Comment = types.StringValue(*acl.UserComment)
+	case acl.Comment != nil:
+		*access.Comment = types.StringValue(*acl.Comment)
+	default:
+		*access.Comment = types.StringNull()
+	}
+}
+
+// accessACLID generates the ID of an access from its owner parts, e.g. the group, and the target of the access, either
+// its IP formatted by formatIPForID or its hostname.
+func accessACLID(owners []string, target string, access accessACL) string {
+	id := fmt.Sprintf("%s:%s:%s:%s",
+		strings.Join(owners, ":"),
+		target,
+		access.Port.ValueString(),
+		access.User.ValueString(),
+	)
+
+	// Add protocol if present
+	if !access.Protocol.IsNull() {
+		id = fmt.Sprintf("%s:%s", id, access.Protocol.ValueString())
+	}
+
+	// Add remote_port if present (only valid with portforward protocol)
+	if !access.RemotePort.IsNull() {
+		id = fmt.Sprintf("%s:%d", id, access.RemotePort.ValueInt64())
+	}
+
+	if !access.ProxyIP.IsNull() {
+		id = fmt.Sprintf("%s:%s:%s:%s",
+			id,
+			formatIPForID(access.ProxyIP.ValueString()),
+			access.ProxyPort.ValueString(),
+			access.ProxyUser.ValueString(),
+		)
+	}
+
+	return id
+}
+
+// importAccessACL imports an access from an ID made of the given owner attributes followed by one of:
+//   - ip:port:user
+//   - ip:port:user:protocol
+//   - ip:port:user:protocol:remote_port (when protocol is "portforward")
+//   - ip:port:user:proxy_ip:proxy_port:proxy_user
+//   - ip:port:user:protocol:proxy_ip:proxy_port:proxy_user
+//   - ip:port:user:protocol:remote_port:proxy_ip:proxy_port:proxy_user (when protocol is "portforward")
+func importAccessACL(ctx context.Context, importID string, owners []string, resp *resource.ImportStateResponse) {
+	parts := parseImportID(importID)
+
+	if len(parts) < len(owners)+3 || len(parts) > len(owners)+8 {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID in the format '%[1]s:ip:port:user', '%[1]s:ip:port:user:protocol', '%[1]s:ip:port:user:protocol:remote_port', '%[1]s:ip:port:user:proxy_ip:proxy_port:proxy_user', '%[1]s:ip:port:user:protocol:proxy_ip:proxy_port:proxy_user', or '%[1]s:ip:port:user:protocol:remote_port:proxy_ip:proxy_port:proxy_user', got: %[2]s", strings.Join(owners, ":"), importID),
+		)
+		return
+	}
+
+	for i, owner := range owners {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(owner), parts[i])...)
+	}
+	parts = parts[len(owners):]
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("ip"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("port"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user"), parts[2])...)
+
+	var proxy []string
+	switch len(parts) {
+	case 4:
+		// protocol
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("protocol"), parts[3])...)
+	case 5, 8:
+		// protocol:remote_port, optionally followed by the proxy
+		protocol := parts[3]
+		if protocol != "portforward" {
+			resp.Diagnostics.AddError(
+				"Invalid Import ID",
+				fmt.Sprintf("When specifying remote_port, protocol must be 'portforward', got: %s", protocol),
+			)
+			return
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("protocol"), protocol)...)
+		remotePort, err := strconv.ParseInt(parts[4], 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Import ID",
+				fmt.Sprintf("Invalid remote_port value '%s': %s", parts[4], err.Error()),
+			)
+			return
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("remote_port"), remotePort)...)
+		proxy = parts[5:]
+	case 6:
+		// proxy_ip:proxy_port:proxy_user
+		proxy = parts[3:]
+	case 7:
+		// protocol:proxy_ip:proxy_port:proxy_user
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("protocol"), parts[3])...)
+		proxy = parts[4:]
+	}
+
+	if len(proxy) == 3 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("proxy_ip"), proxy[0])...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("proxy_port"), proxy[1])...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("proxy_user"), proxy[2])...)
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), importID)...)
+}
@@ -0,0 +1,142 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/adfinis/terraform-provider-bastion/internal/provider/customtypes"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchesACL(t *testing.T) {
+	root := "root"
+	admin := "admin"
+	protocolUser := "!sftp"
+	proxyIP := "10.0.0.1"
+	proxyUser := "proxy_user"
+
+	testCases := []struct {
+		name     string
+		model    GroupServerResourceModel
+		acl      bastion.ACL
+		expected bool
+	}{
+		{
+			name: "wildcard port and user",
+			model: GroupServerResourceModel{
+				IP:         customtypes.NewIPAddressValue("192.168.1.100"),
+				Port:       types.StringValue("*"),
+				User:       types.StringValue("*"),
+				Protocol:   types.StringNull(),
+				ProxyIP:    customtypes.NewIPAddressNull(),
+				RemotePort: types.Int64Null(),
+			},
+			acl:      bastion.ACL{IP: "192.168.1.100"},
+			expected: true,
+		},
+		{
+			name: "canonical IP",
+			model: GroupServerResourceModel{
+				IP:         customtypes.NewIPAddressValue("2001:0db8::0001"),
+				Port:       types.StringValue("22"),
+				User:       types.StringValue("root"),
+				Protocol:   types.StringNull(),
+				ProxyIP:    customtypes.NewIPAddressNull(),
+				RemotePort: types.Int64Null(),
+			},
+			acl:      bastion.ACL{IP: "2001:db8::1", Port: bastion.NewPort("22"), User: &root},
+			expected: true,
+		},
+		{
+			name: "protocol",
+			model: GroupServerResourceModel{
+				IP:         customtypes.NewIPAddressValue("192.168.1.100"),
+				Port:       types.StringValue("22"),
+				User:       types.StringNull(),
+				Protocol:   types.StringValue("sftp"),
+				ProxyIP:    customtypes.NewIPAddressNull(),
+				RemotePort: types.Int64Null(),
+			},
+			acl:      bastion.ACL{IP: "192.168.1.100", Port: bastion.NewPort("22"), User: &protocolUser},
+			expected: true,
+		},
+		{
+			name: "different user",
+			model: GroupServerResourceModel{
+				IP:         customtypes.NewIPAddressValue("192.168.1.100"),
+				Port:       types.StringValue("22"),
+				User:       types.StringValue("root"),
+				Protocol:   types.StringNull(),
+				ProxyIP:    customtypes.NewIPAddressNull(),
+				RemotePort: types.Int64Null(),
+			},
+			acl:      bastion.ACL{IP: "192.168.1.100", Port: bastion.NewPort("22"), User: &admin},
+			expected: false,
+		},
+		{
+			name: "proxy",
+			model: GroupServerResourceModel{
+				IP:         customtypes.NewIPAddressValue("192.168.1.100"),
+				Port:       types.StringValue("22"),
+				User:       types.StringValue("root"),
+				Protocol:   types.StringNull(),
+				ProxyIP:    customtypes.NewIPAddressValue(proxyIP),
+				ProxyPort:  types.StringValue("*"),
+				ProxyUser:  types.StringValue(proxyUser),
+				RemotePort: types.Int64Null(),
+			},
+			acl:      bastion.ACL{IP: "192.168.1.100", Port: bastion.NewPort("22"), User: &root, ProxyIP: &proxyIP, ProxyUser: &proxyUser},
+			expected: true,
+		},
+		{
+			name: "missing proxy",
+			model: GroupServerResourceModel{
+				IP:         customtypes.NewIPAddressValue("192.168.1.100"),
+				Port:       types.StringValue("22"),
+				User:       types.StringValue("root"),
+				Protocol:   types.StringNull(),
+				ProxyIP:    customtypes.NewIPAddressValue(proxyIP),
+				ProxyPort:  types.StringValue("22"),
+				ProxyUser:  types.StringValue(proxyUser),
+				RemotePort: types.Int64Null(),
+			},
+			acl:      bastion.ACL{IP: "192.168.1.100", Port: bastion.NewPort("22"), User: &root},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, matchesACL(tc.model.acl(), &tc.acl))
+		})
+	}
+}
+
+func TestFlattenACL(t *testing.T) {
+	protocolUser := "!scpupload"
+	comment := "added"
+	userComment := "listed"
+
+	var added AccountPersonalAccessResourceModel
+	flattenACL(added.acl(), &bastion.ACL{IP: "192.168.1.100", User: &protocolUser, Comment: &comment})
+
+	assert.Equal(t, types.StringValue("*"), added.Port)
+	assert.Equal(t, types.StringNull(), added.User)
+	assert.Equal(t, types.StringValue("scpupload"), added.Protocol)
+	assert.Equal(t, customtypes.NewIPAddressNull(), added.ProxyIP)
+	assert.Equal(t, types.Int64Null(), added.RemotePort)
+	assert.Equal(t, types.StringValue(comment), added.Comment)
+
+	listed := GroupGuestAccessResourceModel{ProxyPort: types.StringValue("*")}
+	flattenACL(listed.acl(), &bastion.ACL{IP: "192.168.1.100", Port: bastion.NewPort("22"), Comment: &comment, UserComment: &userComment})
+
+	assert.Equal(t, types.StringValue("22"), listed.Port)
+	assert.Equal(t, types.StringValue("*"), listed.User)
+	assert.Equal(t, types.StringNull(), listed.Protocol)
+	assert.Equal(t, types.StringValue("*"), listed.ProxyPort)
+	assert.Equal(t, types.StringValue(userComment), listed.Comment)
+}
@@ -0,0 +1,169 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package customtypes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ basetypes.StringTypable = DurationType{}
+var _ basetypes.StringValuableWithSemanticEquals = Duration{}
+var _ xattr.ValidateableAttribute = Duration{}
+
+// DurationType is an attribute type for durations like 90m or 1d2h, as accepted by The Bastion.
+type DurationType struct {
+	basetypes.StringType
+}
+
+// String returns a human readable string of the type name.
+func (t DurationType) String() string {
+	return "customtypes.DurationType"
+}
+
+// ValueType returns the Value type.
+func (t DurationType) ValueType(ctx context.Context) attr.Value {
+	return Duration{}
+}
+
+// Equal returns true if the given type is equivalent.
+func (t DurationType) Equal(o attr.Type) bool {
+	other, ok := o.(DurationType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+// ValueFromString returns a StringValuable type given a StringValue.
+func (t DurationType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return Duration{StringValue: in}, nil
+}
+
+// ValueFromTerraform returns a Value given a tftypes.Value.
+func (t DurationType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+
+	return stringValuable, nil
+}
+
+// Duration is an attribute value for durations like 90m or 1d2h.
+// Durations designating the same number of seconds are semantically equal, so "90m" and "1h30m" give no diff.
+type Duration struct {
+	basetypes.StringValue
+}
+
+// NewDurationNull creates a Duration with a null value.
+func NewDurationNull() Duration {
+	return Duration{StringValue: basetypes.NewStringNull()}
+}
+
+// NewDurationUnknown creates a Duration with an unknown value.
+func NewDurationUnknown() Duration {
+	return Duration{StringValue: basetypes.NewStringUnknown()}
+}
+
+// NewDurationValue creates a Duration with a known value.
+func NewDurationValue(value bastion.Duration) Duration {
+	return Duration{StringValue: basetypes.NewStringValue(value.String())}
+}
+
+// NewDurationPointerValue creates a Duration with a null value if nil or a known value.
+func NewDurationPointerValue(value *bastion.Duration) Duration {
+	if value == nil {
+		return NewDurationNull()
+	}
+	return NewDurationValue(*value)
+}
+
+// Type returns a DurationType.
+func (v Duration) Type(ctx context.Context) attr.Type {
+	return DurationType{}
+}
+
+// Equal returns true if the given value is equivalent.
+func (v Duration) Equal(o attr.Value) bool {
+	other, ok := o.(Duration)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals returns true if both durations designate the same number of seconds.
+func (v Duration) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(Duration)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			"An unexpected value type was received while performing semantic equality checks. "+
+				"Please report this to the provider developers.\n\n"+
+				"Expected Value Type: "+fmt.Sprintf("%T", v)+"\n"+
+				"Got Value Type: "+fmt.Sprintf("%T", newValuable),
+		)
+		return false, diags
+	}
+
+	prior, err := bastion.ParseDuration(v.ValueString())
+	if err != nil {
+		return false, diags
+	}
+	current, err := bastion.ParseDuration(newValue.ValueString())
+	if err != nil {
+		return false, diags
+	}
+
+	return prior == current, diags
+}
+
+// ValidateAttribute checks that the value is a valid duration.
+func (v Duration) ValidateAttribute(ctx context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+
+	if _, err := bastion.ParseDuration(v.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			err.Error(),
+		)
+	}
+}
+
+// ValueDuration returns the duration, zero if the value is null, unknown or invalid.
+// Invalid values are rejected by ValidateAttribute beforehand.
+func (v Duration) ValueDuration() bastion.Duration {
+	if v.IsNull() || v.IsUnknown() {
+		return 0
+	}
+
+	d, err := bastion.ParseDuration(v.ValueString())
+	if err != nil {
+		return 0
+	}
+	return d
+}
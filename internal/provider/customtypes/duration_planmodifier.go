@@ -0,0 +1,59 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package customtypes
+
+import (
+	"context"
+
+	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ planmodifier.String = durationPlanModifier{}
+
+// DurationPlanModifier returns a plan modifier keeping the prior duration when the configured one designates the same
+// number of seconds, e.g. when "90m" is replaced by "1h30m", so that equal durations give no diff.
+// Terraform only allows providers to change the planned value of computed attributes, so it must be used on Optional
+// and Computed attributes. A null configuration is planned as null, like for attributes which are only Optional.
+func DurationPlanModifier() planmodifier.String {
+	return durationPlanModifier{}
+}
+
+type durationPlanModifier struct{}
+
+// Description returns a plain text description of the modifier's behavior.
+func (m durationPlanModifier) Description(ctx context.Context) string {
+	return "Keeps the prior duration when the configured duration is equal."
+}
+
+// MarkdownDescription returns a markdown formatted description of the modifier's behavior.
+func (m durationPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+// PlanModifyString implements the plan modification logic.
+func (m durationPlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.ConfigValue.IsNull() {
+		resp.PlanValue = types.StringNull()
+		return
+	}
+
+	if req.ConfigValue.IsUnknown() || req.StateValue.IsNull() || req.StateValue.IsUnknown() {
+		return
+	}
+
+	configured, err := bastion.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil {
+		return
+	}
+	prior, err := bastion.ParseDuration(req.StateValue.ValueString())
+	if err != nil {
+		return
+	}
+
+	if configured == prior {
+		resp.PlanValue = req.StateValue
+	}
+}
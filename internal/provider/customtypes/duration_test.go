@@ -0,0 +1,195 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package customtypes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDurationStringSemanticEquals(t *testing.T) {
+	testCases := []struct {
+		name     string
+		prior    string
+		current  string
+		expected bool
+	}{
+		{
+			name:     "same string",
+			prior:    "90m",
+			current:  "90m",
+			expected: true,
+		},
+		{
+			name:     "seconds and duration",
+			prior:    "3600",
+			current:  "1h",
+			expected: true,
+		},
+		{
+			name:     "different units",
+			prior:    "90m",
+			current:  "1h30m",
+			expected: true,
+		},
+		{
+			name:     "weeks and days",
+			prior:    "1w",
+			current:  "7d",
+			expected: true,
+		},
+		{
+			name:     "different durations",
+			prior:    "1h",
+			current:  "1h1s",
+			expected: false,
+		},
+		{
+			name:     "invalid duration",
+			prior:    "1h",
+			current:  "one hour",
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			prior := Duration{StringValue: types.StringValue(tc.prior)}
+			current := Duration{StringValue: types.StringValue(tc.current)}
+
+			equal, diags := prior.StringSemanticEquals(context.Background(), current)
+			assert.False(t, diags.HasError())
+			assert.Equal(t, tc.expected, equal)
+		})
+	}
+}
+
+func TestDurationValidateAttribute(t *testing.T) {
+	testCases := []struct {
+		name      string
+		value     Duration
+		expectErr bool
+	}{
+		{
+			name:  "seconds",
+			value: Duration{StringValue: types.StringValue("3600")},
+		},
+		{
+			name:  "special value",
+			value: Duration{StringValue: types.StringValue("-1")},
+		},
+		{
+			name:  "duration",
+			value: Duration{StringValue: types.StringValue("1d2h30m15s")},
+		},
+		{
+			name:  "null",
+			value: NewDurationNull(),
+		},
+		{
+			name:  "unknown",
+			value: NewDurationUnknown(),
+		},
+		{
+			name:      "unknown unit",
+			value:     Duration{StringValue: types.StringValue("2y")},
+			expectErr: true,
+		},
+		{
+			name:      "missing unit",
+			value:     Duration{StringValue: types.StringValue("1h30")},
+			expectErr: true,
+		},
+		{
+			name:      "empty",
+			value:     Duration{StringValue: types.StringValue("")},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := &xattr.ValidateAttributeResponse{}
+			tc.value.ValidateAttribute(context.Background(), xattr.ValidateAttributeRequest{Path: path.Root("ttl")}, resp)
+			assert.Equal(t, tc.expectErr, resp.Diagnostics.HasError())
+		})
+	}
+}
+
+func TestNewDurationValue(t *testing.T) {
+	testCases := []struct {
+		seconds  int64
+		expected string
+	}{
+		{seconds: 90, expected: "1m30s"},
+		{seconds: 3600, expected: "1h"},
+		{seconds: 93784, expected: "1d2h3m4s"},
+		{seconds: 604800, expected: "7d"},
+		{seconds: 0, expected: "0"},
+		{seconds: -1, expected: "-1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			value := NewDurationValue(bastion.Duration(tc.seconds))
+			assert.Equal(t, tc.expected, value.ValueString())
+			assert.Equal(t, bastion.Duration(tc.seconds), value.ValueDuration())
+		})
+	}
+}
+
+func TestDurationPlanModifier(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   types.String
+		state    types.String
+		expected types.String
+	}{
+		{
+			name:     "equal duration keeps the state",
+			config:   types.StringValue("1h"),
+			state:    types.StringValue("3600"),
+			expected: types.StringValue("3600"),
+		},
+		{
+			name:     "different duration",
+			config:   types.StringValue("2h"),
+			state:    types.StringValue("3600"),
+			expected: types.StringValue("2h"),
+		},
+		{
+			name:     "null configuration",
+			config:   types.StringNull(),
+			state:    types.StringValue("3600"),
+			expected: types.StringNull(),
+		},
+		{
+			name:     "create",
+			config:   types.StringValue("90m"),
+			state:    types.StringNull(),
+			expected: types.StringValue("90m"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := planmodifier.StringRequest{
+				ConfigValue: tc.config,
+				StateValue:  tc.state,
+				PlanValue:   tc.config,
+			}
+			resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+
+			DurationPlanModifier().PlanModifyString(context.Background(), req, resp)
+			assert.Equal(t, tc.expected, resp.PlanValue)
+		})
+	}
+}
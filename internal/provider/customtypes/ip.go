@@ -0,0 +1,165 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package customtypes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ basetypes.StringTypable = IPAddressType{}
+var _ basetypes.StringValuableWithSemanticEquals = IPAddress{}
+var _ xattr.ValidateableAttribute = IPAddress{}
+
+// IPAddressType is an attribute type for IP addresses and subnets, as accepted by The Bastion.
+type IPAddressType struct {
+	basetypes.StringType
+}
+
+// String returns a human readable string of the type name.
+func (t IPAddressType) String() string {
+	return "customtypes.IPAddressType"
+}
+
+// ValueType returns the Value type.
+func (t IPAddressType) ValueType(ctx context.Context) attr.Value {
+	return IPAddress{}
+}
+
+// Equal returns true if the given type is equivalent.
+func (t IPAddressType) Equal(o attr.Type) bool {
+	other, ok := o.(IPAddressType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+// ValueFromString returns a StringValuable type given a StringValue.
+func (t IPAddressType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return IPAddress{StringValue: in}, nil
+}
+
+// ValueFromTerraform returns a Value given a tftypes.Value.
+func (t IPAddressType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+
+	return stringValuable, nil
+}
+
+// IPAddress is an attribute value for IP addresses and subnets.
+// Values designating the same target are semantically equal, so "10.0.0.1/32" and "10.0.0.1" give no diff.
+type IPAddress struct {
+	basetypes.StringValue
+}
+
+// NewIPAddressNull creates an IPAddress with a null value.
+func NewIPAddressNull() IPAddress {
+	return IPAddress{StringValue: basetypes.NewStringNull()}
+}
+
+// NewIPAddressUnknown creates an IPAddress with an unknown value.
+func NewIPAddressUnknown() IPAddress {
+	return IPAddress{StringValue: basetypes.NewStringUnknown()}
+}
+
+// NewIPAddressValue creates an IPAddress with a known value.
+func NewIPAddressValue(value string) IPAddress {
+	return IPAddress{StringValue: basetypes.NewStringValue(value)}
+}
+
+// NewIPAddressPointerValue creates an IPAddress with a null value if nil or a known value.
+func NewIPAddressPointerValue(value *string) IPAddress {
+	if value == nil {
+		return NewIPAddressNull()
+	}
+	return NewIPAddressValue(*value)
+}
+
+// Type returns an IPAddressType.
+func (v IPAddress) Type(ctx context.Context) attr.Type {
+	return IPAddressType{}
+}
+
+// Equal returns true if the given value is equivalent.
+func (v IPAddress) Equal(o attr.Value) bool {
+	other, ok := o.(IPAddress)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals returns true if both values designate the same IP address or subnet.
+func (v IPAddress) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(IPAddress)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			"An unexpected value type was received while performing semantic equality checks. "+
+				"Please report this to the provider developers.\n\n"+
+				"Expected Value Type: "+fmt.Sprintf("%T", v)+"\n"+
+				"Got Value Type: "+fmt.Sprintf("%T", newValuable),
+		)
+		return false, diags
+	}
+
+	prior, err := bastion.CanonicalIP(v.ValueString())
+	if err != nil {
+		return false, diags
+	}
+	current, err := bastion.CanonicalIP(newValue.ValueString())
+	if err != nil {
+		return false, diags
+	}
+
+	return prior == current, diags
+}
+
+// ValidateAttribute checks that the value is a valid IP address or subnet.
+func (v IPAddress) ValidateAttribute(ctx context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+
+	if _, err := bastion.CanonicalIP(v.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid IP Address",
+			err.Error(),
+		)
+	}
+}
+
+// ValueCanonical returns the canonical form of the IP address or subnet, as stored by The Bastion.
+// Invalid values are returned as is, they are rejected by ValidateAttribute beforehand.
+func (v IPAddress) ValueCanonical() string {
+	canonical, err := bastion.CanonicalIP(v.ValueString())
+	if err != nil {
+		return v.ValueString()
+	}
+	return canonical
+}
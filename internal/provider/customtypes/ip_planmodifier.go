@@ -0,0 +1,43 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package customtypes
+
+import (
+	"context"
+
+	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+)
+
+var _ planmodifier.String = ipAddressPlanModifier{}
+
+// IPAddressPlanModifier returns a plan modifier keeping the prior IP when the configured one designates the same
+// address or subnet, e.g. when "10.0.0.1" is replaced by "10.0.0.1/32", so that equal IPs give no diff.
+// It must come before RequiresReplace, which would otherwise replace the resource for a mere change of spelling.
+func IPAddressPlanModifier() planmodifier.String {
+	return ipAddressPlanModifier{}
+}
+
+type ipAddressPlanModifier struct{}
+
+// Description returns a plain text description of the modifier's behavior.
+func (m ipAddressPlanModifier) Description(ctx context.Context) string {
+	return "Keeps the prior IP when the configured IP is equal."
+}
+
+// MarkdownDescription returns a markdown formatted description of the modifier's behavior.
+func (m ipAddressPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+// PlanModifyString implements the plan modification logic.
+func (m ipAddressPlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() || req.StateValue.IsNull() || req.StateValue.IsUnknown() {
+		return
+	}
+
+	if bastion.EqualIP(req.ConfigValue.ValueString(), req.StateValue.ValueString()) {
+		resp.PlanValue = req.StateValue
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package customtypes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPAddressStringSemanticEquals(t *testing.T) {
+	testCases := []struct {
+		name     string
+		prior    string
+		current  string
+		expected bool
+	}{
+		{
+			name:     "same address",
+			prior:    "10.0.0.1",
+			current:  "10.0.0.1",
+			expected: true,
+		},
+		{
+			name:     "IPv4 host prefix",
+			prior:    "10.0.0.1/32",
+			current:  "10.0.0.1",
+			expected: true,
+		},
+		{
+			name:     "leading zeros",
+			prior:    "10.00.0.001",
+			current:  "10.0.0.1",
+			expected: true,
+		},
+		{
+			name:     "uncompressed IPv6",
+			prior:    "2001:0db8:0000:0000:0000:0000:0000:0001",
+			current:  "2001:db8::1",
+			expected: true,
+		},
+		{
+			name:     "IPv6 host prefix",
+			prior:    "2001:DB8::1/128",
+			current:  "2001:db8::1",
+			expected: true,
+		},
+		{
+			name:     "unmasked subnet",
+			prior:    "192.168.2.1/24",
+			current:  "192.168.2.0/24",
+			expected: true,
+		},
+		{
+			name:     "different addresses",
+			prior:    "10.0.0.1",
+			current:  "10.0.0.2",
+			expected: false,
+		},
+		{
+			name:     "different prefix lengths",
+			prior:    "192.168.2.0/24",
+			current:  "192.168.2.0/25",
+			expected: false,
+		},
+		{
+			name:     "invalid address",
+			prior:    "10.0.0.1",
+			current:  "fortress.example.com",
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			prior := NewIPAddressValue(tc.prior)
+			current := NewIPAddressValue(tc.current)
+
+			equal, diags := prior.StringSemanticEquals(context.Background(), current)
+			assert.False(t, diags.HasError())
+			assert.Equal(t, tc.expected, equal)
+		})
+	}
+}
+
+func TestIPAddressValidateAttribute(t *testing.T) {
+	testCases := []struct {
+		name      string
+		value     IPAddress
+		expectErr bool
+	}{
+		{
+			name:  "IPv4",
+			value: NewIPAddressValue("192.168.1.100"),
+		},
+		{
+			name:  "IPv6",
+			value: NewIPAddressValue("2001:db8::1"),
+		},
+		{
+			name:  "subnet",
+			value: NewIPAddressValue("192.168.2.0/24"),
+		},
+		{
+			name:  "null",
+			value: NewIPAddressNull(),
+		},
+		{
+			name:  "unknown",
+			value: NewIPAddressUnknown(),
+		},
+		{
+			name:      "hostname",
+			value:     NewIPAddressValue("fortress.example.com"),
+			expectErr: true,
+		},
+		{
+			name:      "octet out of range",
+			value:     NewIPAddressValue("10.0.0.256"),
+			expectErr: true,
+		},
+		{
+			name:      "prefix length out of range",
+			value:     NewIPAddressValue("10.0.0.0/33"),
+			expectErr: true,
+		},
+		{
+			name:      "zone",
+			value:     NewIPAddressValue("fe80::1%eth0"),
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := &xattr.ValidateAttributeResponse{}
+			tc.value.ValidateAttribute(context.Background(), xattr.ValidateAttributeRequest{Path: path.Root("ip")}, resp)
+			assert.Equal(t, tc.expectErr, resp.Diagnostics.HasError())
+		})
+	}
+}
+
+func TestIPAddressValueCanonical(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected string
+	}{
+		{value: "10.0.0.1/32", expected: "10.0.0.1"},
+		{value: "10.00.0.001", expected: "10.0.0.1"},
+		{value: "2001:0DB8:0:0:0:0:0:1", expected: "2001:db8::1"},
+		{value: "2001:db8::1/128", expected: "2001:db8::1"},
+		{value: "192.168.2.1/24", expected: "192.168.2.0/24"},
+		{value: "2001:db8::/32", expected: "2001:db8::/32"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			assert.Equal(t, tc.expected, NewIPAddressValue(tc.value).ValueCanonical())
+		})
+	}
+}
+
+func TestIPAddressPlanModifier(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   types.String
+		state    types.String
+		expected types.String
+	}{
+		{
+			name:     "host prefix keeps the state",
+			config:   types.StringValue("10.0.0.1/32"),
+			state:    types.StringValue("10.0.0.1"),
+			expected: types.StringValue("10.0.0.1"),
+		},
+		{
+			name:     "uncompressed IPv6 keeps the state",
+			config:   types.StringValue("2001:0db8:0000:0000:0000:0000:0000:0001"),
+			state:    types.StringValue("2001:db8::1"),
+			expected: types.StringValue("2001:db8::1"),
+		},
+		{
+			name:     "different address",
+			config:   types.StringValue("10.0.0.2"),
+			state:    types.StringValue("10.0.0.1"),
+			expected: types.StringValue("10.0.0.2"),
+		},
+		{
+			name:     "null configuration",
+			config:   types.StringNull(),
+			state:    types.StringValue("10.0.0.1"),
+			expected: types.StringNull(),
+		},
+		{
+			name:     "create",
+			config:   types.StringValue("10.0.0.1/32"),
+			state:    types.StringNull(),
+			expected: types.StringValue("10.0.0.1/32"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := planmodifier.StringRequest{
+				ConfigValue: tc.config,
+				StateValue:  tc.state,
+				PlanValue:   tc.config,
+			}
+			resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+
+			IPAddressPlanModifier().PlanModifyString(context.Background(), req, resp)
+			assert.Equal(t, tc.expected, resp.PlanValue)
+		})
+	}
+}
@@ -0,0 +1,373 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &AccountDataSource{}
+var _ datasource.DataSourceWithConfigure = &AccountDataSource{}
+
+// NewAccountDataSource is a helper function to simplify the provider implementation.
+func NewAccountDataSource() datasource.DataSource {
+	return &AccountDataSource{}
+}
+
+// AccountDataSource is the data source implementation.
+type AccountDataSource struct {
+	client *bastion.Client
+}
+
+// accountDataSourceModel describes the data source data model.
+type accountDataSourceModel struct {
+	Account                     types.String             `tfsdk:"account"`
+	UID                         types.Int64              `tfsdk:"uid"`
+	IsActive                    types.Bool               `tfsdk:"is_active"`
+	IsExpired                   types.Bool               `tfsdk:"is_expired"`
+	IsFrozen                    types.Bool               `tfsdk:"is_frozen"`
+	CanConnect                  types.Bool               `tfsdk:"can_connect"`
+	AlreadySeenBefore           types.Bool               `tfsdk:"already_seen_before"`
+	AlwaysActive                types.Bool               `tfsdk:"always_active"`
+	MaxInactiveDays             types.Int64              `tfsdk:"max_inactive_days"`
+	IsAdmin                     types.Bool               `tfsdk:"is_admin"`
+	IsSuperOwner                types.Bool               `tfsdk:"is_super_owner"`
+	IsAuditor                   types.Bool               `tfsdk:"is_auditor"`
+	OshOnly                     types.Bool               `tfsdk:"osh_only"`
+	IdleIgnore                  types.Bool               `tfsdk:"idle_ignore"`
+	PamAuthBypass               types.Bool               `tfsdk:"pam_auth_bypass"`
+	PubkeyAuthOptional          types.Bool               `tfsdk:"pubkey_auth_optional"`
+	GlobalIngressPolicy         types.Bool               `tfsdk:"global_ingress_policy"`
+	AllowedCommands             types.List               `tfsdk:"allowed_commands"`
+	MFATOTPBypass               types.Bool               `tfsdk:"mfa_totp_bypass"`
+	MFATOTPRequired             types.Bool               `tfsdk:"mfa_totp_required"`
+	MFATOTPConfigured           types.Bool               `tfsdk:"mfa_totp_configured"`
+	MFAPasswordBypass           types.Bool               `tfsdk:"mfa_password_bypass"`
+	MFAPasswordRequired         types.Bool               `tfsdk:"mfa_password_required"`
+	MFAPasswordConfigured       types.Bool               `tfsdk:"mfa_password_configured"`
+	PersonalEgressMFARequired   types.String             `tfsdk:"personal_egress_mfa_required"`
+	EgressStrictHostKeyChecking types.String             `tfsdk:"egress_strict_host_key_checking"`
+	EgressSessionMultiplexing   types.String             `tfsdk:"egress_session_multiplexing"`
+	IngressPIVPolicy            types.String             `tfsdk:"ingress_piv_policy"`
+	IngressPIVEnforced          types.Bool               `tfsdk:"ingress_piv_enforced"`
+	IngressPIVGrace             ingressPIVGraceModel     `tfsdk:"ingress_piv_grace"`
+	IsTTLSet                    types.Bool               `tfsdk:"is_ttl_set"`
+	IsTTLExpired                types.Bool               `tfsdk:"is_ttl_expired"`
+	TTLTimestamp                types.Int64              `tfsdk:"ttl_timestamp"`
+	CreationInformation         creationInformationModel `tfsdk:"creation_information"`
+}
+
+// creationInformationModel describes how and when an account was created.
+type creationInformationModel struct {
+	Timestamp      types.Int64  `tfsdk:"timestamp"`
+	Comment        types.String `tfsdk:"comment"`
+	By             types.String `tfsdk:"by"`
+	BastionVersion types.String `tfsdk:"bastion_version"`
+}
+
+// ingressPIVGraceModel describes the PIV grace period of an account.
+type ingressPIVGraceModel struct {
+	Enabled             types.Bool  `tfsdk:"enabled"`
+	ExpirationTimestamp types.Int64 `tfsdk:"expiration_timestamp"`
+	SecondsRemaining    types.Int64 `tfsdk:"seconds_remaining"`
+}
+
+// Metadata returns the data source type name.
+func (d *AccountDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_account"
+}
+
+// Schema defines the schema for the data source.
+func (d *AccountDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads the information of a Bastion account",
+		Attributes: map[string]schema.Attribute{
+			"account": schema.StringAttribute{
+				MarkdownDescription: "The name of the Bastion account",
+				Required:            true,
+			},
+			"uid": schema.Int64Attribute{
+				MarkdownDescription: "The UID of the account",
+				Computed:            true,
+			},
+			"is_active": schema.BoolAttribute{
+				MarkdownDescription: "Whether the account is active",
+				Computed:            true,
+			},
+			"is_expired": schema.BoolAttribute{
+				MarkdownDescription: "Whether the account is expired because of inactivity",
+				Computed:            true,
+			},
+			"is_frozen": schema.BoolAttribute{
+				MarkdownDescription: "Whether the account is frozen",
+				Computed:            true,
+			},
+			"can_connect": schema.BoolAttribute{
+				MarkdownDescription: "Whether the account is currently allowed to connect to The Bastion",
+				Computed:            true,
+			},
+			"already_seen_before": schema.BoolAttribute{
+				MarkdownDescription: "Whether the account has already connected to The Bastion",
+				Computed:            true,
+			},
+			"always_active": schema.BoolAttribute{
+				MarkdownDescription: "Whether the account is always active",
+				Computed:            true,
+			},
+			"max_inactive_days": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of days of inactivity before the account expires",
+				Computed:            true,
+			},
+			"is_admin": schema.BoolAttribute{
+				MarkdownDescription: "Whether the account is a Bastion admin",
+				Computed:            true,
+			},
+			"is_super_owner": schema.BoolAttribute{
+				MarkdownDescription: "Whether the account is a super owner",
+				Computed:            true,
+			},
+			"is_auditor": schema.BoolAttribute{
+				MarkdownDescription: "Whether the account is an auditor",
+				Computed:            true,
+			},
+			"osh_only": schema.BoolAttribute{
+				MarkdownDescription: "Whether the account can only use osh (bastion) commands",
+				Computed:            true,
+			},
+			"idle_ignore": schema.BoolAttribute{
+				MarkdownDescription: "Whether idle timeouts are ignored for this account",
+				Computed:            true,
+			},
+			"pam_auth_bypass": schema.BoolAttribute{
+				MarkdownDescription: "Whether PAM authentication is bypassed for this account",
+				Computed:            true,
+			},
+			"pubkey_auth_optional": schema.BoolAttribute{
+				MarkdownDescription: "Whether public key authentication is optional for this account",
+				Computed:            true,
+			},
+			"global_ingress_policy": schema.BoolAttribute{
+				MarkdownDescription: "Whether the global ingress policy applies to this account",
+				Computed:            true,
+			},
+			"allowed_commands": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The restricted commands granted to the account",
+				Computed:            true,
+			},
+			"mfa_totp_bypass": schema.BoolAttribute{
+				MarkdownDescription: "Whether the account bypasses TOTP MFA",
+				Computed:            true,
+			},
+			"mfa_totp_required": schema.BoolAttribute{
+				MarkdownDescription: "Whether TOTP MFA is required for the account",
+				Computed:            true,
+			},
+			"mfa_totp_configured": schema.BoolAttribute{
+				MarkdownDescription: "Whether the account has configured TOTP MFA",
+				Computed:            true,
+			},
+			"mfa_password_bypass": schema.BoolAttribute{
+				MarkdownDescription: "Whether the account bypasses password MFA",
+				Computed:            true,
+			},
+			"mfa_password_required": schema.BoolAttribute{
+				MarkdownDescription: "Whether password MFA is required for the account",
+				Computed:            true,
+			},
+			"mfa_password_configured": schema.BoolAttribute{
+				MarkdownDescription: "Whether the account has configured password MFA",
+				Computed:            true,
+			},
+			"personal_egress_mfa_required": schema.StringAttribute{
+				MarkdownDescription: "Personal egress MFA policy. One of password, totp, any, none.",
+				Computed:            true,
+			},
+			"egress_strict_host_key_checking": schema.StringAttribute{
+				MarkdownDescription: "Egress strict host key checking policy, null when the global default applies",
+				Computed:            true,
+			},
+			"egress_session_multiplexing": schema.StringAttribute{
+				MarkdownDescription: "Egress session multiplexing policy, null when the global default applies",
+				Computed:            true,
+			},
+			"ingress_piv_policy": schema.StringAttribute{
+				MarkdownDescription: "The PIV policy of the account ingress keys",
+				Computed:            true,
+			},
+			"ingress_piv_enforced": schema.BoolAttribute{
+				MarkdownDescription: "Whether PIV is enforced for the account ingress keys",
+				Computed:            true,
+			},
+			"ingress_piv_grace": schema.SingleNestedAttribute{
+				MarkdownDescription: "The PIV grace period of the account",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"enabled": schema.BoolAttribute{
+						MarkdownDescription: "Whether a PIV grace period is active",
+						Computed:            true,
+					},
+					"expiration_timestamp": schema.Int64Attribute{
+						MarkdownDescription: "Unix timestamp at which the grace period ends",
+						Computed:            true,
+					},
+					"seconds_remaining": schema.Int64Attribute{
+						MarkdownDescription: "Seconds remaining until the grace period ends",
+						Computed:            true,
+					},
+				},
+			},
+			"is_ttl_set": schema.BoolAttribute{
+				MarkdownDescription: "Whether the account has a TTL",
+				Computed:            true,
+			},
+			"is_ttl_expired": schema.BoolAttribute{
+				MarkdownDescription: "Whether the TTL of the account is expired",
+				Computed:            true,
+			},
+			"ttl_timestamp": schema.Int64Attribute{
+				MarkdownDescription: "Unix timestamp at which the TTL of the account expires",
+				Computed:            true,
+			},
+			"creation_information": schema.SingleNestedAttribute{
+				MarkdownDescription: "Information about the creation of the account",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"timestamp": schema.Int64Attribute{
+						MarkdownDescription: "Unix timestamp of the account creation",
+						Computed:            true,
+					},
+					"comment": schema.StringAttribute{
+						MarkdownDescription: "The comment given on account creation",
+						Computed:            true,
+					},
+					"by": schema.StringAttribute{
+						MarkdownDescription: "The account which created the account",
+						Computed:            true,
+					},
+					"bastion_version": schema.StringAttribute{
+						MarkdownDescription: "The Bastion version at the time of creation",
+						Computed:            true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the bastion client to the data source.
+func (d *AccountDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*bastion.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *bastion.Client type for data source configuration.",
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *AccountDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data accountDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	account, err := d.client.AccountInfo(data.Account.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Bastion Account",
+			err.Error(),
+		)
+		return
+	}
+
+	data.Account = types.StringValue(account.Account)
+	data.UID = types.Int64Value(int64(account.UID))
+	data.IsActive = types.BoolValue(account.IsActive.Bool())
+	data.IsExpired = types.BoolValue(account.IsExpired.Bool())
+	data.IsFrozen = types.BoolValue(account.IsFrozen.Bool())
+	data.CanConnect = types.BoolValue(account.CanConnect.Bool())
+	data.AlreadySeenBefore = types.BoolValue(account.AlreadySeenBefore.Bool())
+	data.AlwaysActive = types.BoolValue(account.AlwaysActive.Bool())
+	data.IsAdmin = types.BoolValue(account.IsAdmin.Bool())
+	data.IsSuperOwner = types.BoolValue(account.IsSuperOwner.Bool())
+	data.IsAuditor = types.BoolValue(account.IsAuditor.Bool())
+	data.OshOnly = types.BoolValue(account.OshOnly.Bool())
+	data.IdleIgnore = types.BoolValue(account.IdleIgnore.Bool())
+	data.PamAuthBypass = types.BoolValue(account.PamAuthBypass.Bool())
+	data.PubkeyAuthOptional = types.BoolValue(account.PubkeyAuthOptional.Bool())
+	data.GlobalIngressPolicy = types.BoolValue(account.GlobalIngressPolicy.Bool())
+	data.MFATOTPBypass = types.BoolValue(account.MFATOTPBypass.Bool())
+	data.MFATOTPRequired = types.BoolValue(account.MFATOTPRequired.Bool())
+	data.MFATOTPConfigured = types.BoolValue(account.MFATOTPConfigured.Bool())
+	data.MFAPasswordBypass = types.BoolValue(account.MFAPasswordBypass.Bool())
+	data.MFAPasswordRequired = types.BoolValue(account.MFAPasswordRequired.Bool())
+	data.MFAPasswordConfigured = types.BoolValue(account.MFAPasswordConfigured.Bool())
+	data.PersonalEgressMFARequired = types.StringValue(string(account.PersonalEgressMFARequired))
+	data.EgressStrictHostKeyChecking = types.StringNull()
+	if account.EgressStrictHostKeyChecking != nil {
+		data.EgressStrictHostKeyChecking = types.StringValue(string(*account.EgressStrictHostKeyChecking))
+	}
+	data.EgressSessionMultiplexing = types.StringNull()
+	if account.EgressSessionMultiplexing != nil {
+		data.EgressSessionMultiplexing = types.StringValue(string(*account.EgressSessionMultiplexing))
+	}
+	data.IngressPIVPolicy = types.StringValue(string(account.IngressPIVPolicy))
+	data.IngressPIVEnforced = types.BoolValue(account.IngressPIVEnforced.Bool())
+	data.IsTTLSet = types.BoolValue(account.IsTTLSet.Bool())
+	data.IsTTLExpired = types.BoolValue(account.IsTTLExpired.Bool())
+	data.TTLTimestamp = types.Int64Value(int64(account.TTTLTimestamp))
+
+	data.IngressPIVGrace = ingressPIVGraceModel{
+		Enabled:             types.BoolValue(account.IngressPIVGrace.Enabled.Bool()),
+		ExpirationTimestamp: types.Int64Value(int64(account.IngressPIVGrace.ExpirationTimestamp)),
+		SecondsRemaining:    types.Int64Value(int64(account.IngressPIVGrace.SecondsRemaining)),
+	}
+
+	data.CreationInformation = creationInformationModel{
+		Timestamp:      types.Int64Value(int64(account.CreationInformation.Timestamp)),
+		Comment:        types.StringValue(account.CreationInformation.Comment),
+		By:             types.StringValue(account.CreationInformation.By),
+		BastionVersion: types.StringValue(account.CreationInformation.BastionVersion),
+	}
+
+	allowedCommands, diags := types.ListValueFrom(ctx, types.StringType, account.AllowedCommands)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.AllowedCommands = allowedCommands
+
+	data.MaxInactiveDays = types.Int64Null()
+	if account.MaxInactiveDays != "" {
+		maxInactiveDays, err := strconv.Atoi(account.MaxInactiveDays)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Converting Max Inactive Days",
+				fmt.Sprintf("Could not convert max_inactive_days '%s' to integer: %s", account.MaxInactiveDays, err.Error()),
+			)
+			return
+		}
+		data.MaxInactiveDays = types.Int64Value(int64(maxInactiveDays))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,306 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/netip"
+	"strings"
+
+	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/adfinis/terraform-provider-bastion/internal/provider/customtypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &AccountAccessesDataSource{}
+var _ datasource.DataSourceWithConfigure = &AccountAccessesDataSource{}
+
+// NewAccountAccessesDataSource is a helper function to simplify the provider implementation.
+func NewAccountAccessesDataSource() datasource.DataSource {
+	return &AccountAccessesDataSource{}
+}
+
+// AccountAccessesDataSource is the data source implementation.
+type AccountAccessesDataSource struct {
+	client *bastion.Client
+}
+
+// accountAccessesDataSourceModel describes the data source data model.
+type accountAccessesDataSourceModel struct {
+	Account  types.String          `tfsdk:"account"`
+	Type     types.String          `tfsdk:"type"`
+	Group    types.String          `tfsdk:"group"`
+	IP       customtypes.IPAddress `tfsdk:"ip"`
+	Accesses []accountAccessModel  `tfsdk:"accesses"`
+}
+
+// accountAccessModel describes a single flattened access of an account.
+type accountAccessModel struct {
+	Type          types.String          `tfsdk:"type"`
+	Group         types.String          `tfsdk:"group"`
+	IP            customtypes.IPAddress `tfsdk:"ip"`
+	Port          types.String          `tfsdk:"port"`
+	User          types.String          `tfsdk:"user"`
+	Protocol      types.String          `tfsdk:"protocol"`
+	ProxyIP       customtypes.IPAddress `tfsdk:"proxy_ip"`
+	ProxyPort     types.String          `tfsdk:"proxy_port"`
+	ProxyUser     types.String          `tfsdk:"proxy_user"`
+	RemotePort    types.Int64           `tfsdk:"remote_port"`
+	Comment       types.String          `tfsdk:"comment"`
+	ForceKey      types.String          `tfsdk:"force_key"`
+	ForcePassword types.String          `tfsdk:"force_password"`
+	AddedBy       types.String          `tfsdk:"added_by"`
+	AddedDate     types.String          `tfsdk:"added_date"`
+	Expiry        types.Int64           `tfsdk:"expiry"`
+}
+
+// Metadata returns the data source type name.
+func (d *AccountAccessesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_account_accesses"
+}
+
+// Schema defines the schema for the data source.
+func (d *AccountAccessesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists every server access of a Bastion account, whether it is a personal access or granted through a group membership or a group guest access.",
+		Attributes: map[string]schema.Attribute{
+			"account": schema.StringAttribute{
+				MarkdownDescription: "The name of the Bastion account",
+				Required:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Only list the accesses of this type. Valid values: personal, group, group-guest.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("personal", "group", "group-guest"),
+				},
+			},
+			"group": schema.StringAttribute{
+				MarkdownDescription: "Only list the accesses granted through this group",
+				Optional:            true,
+			},
+			"ip": schema.StringAttribute{
+				CustomType:          customtypes.IPAddressType{},
+				MarkdownDescription: "Only list the accesses to this IP or subnet, including subnet accesses containing it",
+				Optional:            true,
+			},
+			"accesses": schema.ListNestedAttribute{
+				MarkdownDescription: "The accesses of the account",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: accountAccessAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the bastion client to the data source.
+func (d *AccountAccessesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*bastion.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *bastion.Client type for data source configuration.",
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *AccountAccessesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data accountAccessesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var target netip.Prefix
+	if !data.IP.IsNull() {
+		prefix, err := parseIPOrPrefix(data.IP.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ip"),
+				"Invalid IP Address",
+				err.Error(),
+			)
+			return
+		}
+		target = prefix
+	}
+
+	accesses, err := d.client.AccountListAccesses(data.Account.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Bastion Account Accesses",
+			err.Error(),
+		)
+		return
+	}
+
+	data.Accesses = []accountAccessModel{}
+	for _, access := range accesses {
+		if !data.Type.IsNull() && data.Type.ValueString() != access.AccessType {
+			continue
+		}
+		if !data.Group.IsNull() && (access.Group == nil || *access.Group != data.Group.ValueString()) {
+			continue
+		}
+
+		for _, acl := range access.ACL {
+			if target.IsValid() && !aclContainsIP(acl.IP, target) {
+				continue
+			}
+			data.Accesses = append(data.Accesses, flattenAccountAccess(access.AccessType, access.Group, &acl))
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// accountAccessAttributes returns the schema attributes of a flattened access.
+func accountAccessAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"type": schema.StringAttribute{
+			MarkdownDescription: "The source of the access: personal, group or group-guest",
+			Computed:            true,
+		},
+		"group": schema.StringAttribute{
+			MarkdownDescription: "The group granting the access, null for personal accesses",
+			Computed:            true,
+		},
+		"ip": schema.StringAttribute{
+			CustomType:          customtypes.IPAddressType{},
+			MarkdownDescription: "IP or subnet of the access target",
+			Computed:            true,
+		},
+		"port": schema.StringAttribute{
+			MarkdownDescription: "Port of the access target, '*' for all ports",
+			Computed:            true,
+		},
+		"user": schema.StringAttribute{
+			MarkdownDescription: "Username for the access, '*' for all users. Null for protocol accesses.",
+			Computed:            true,
+		},
+		"protocol": schema.StringAttribute{
+			MarkdownDescription: "Protocol of the access, null for ssh accesses",
+			Computed:            true,
+		},
+		"proxy_ip": schema.StringAttribute{
+			CustomType:          customtypes.IPAddressType{},
+			MarkdownDescription: "IP of the proxy server",
+			Computed:            true,
+		},
+		"proxy_port": schema.StringAttribute{
+			MarkdownDescription: "Port of the proxy server",
+			Computed:            true,
+		},
+		"proxy_user": schema.StringAttribute{
+			MarkdownDescription: "Username for the proxy server",
+			Computed:            true,
+		},
+		"remote_port": schema.Int64Attribute{
+			MarkdownDescription: "Remote port forwarded from the target server to The Bastion",
+			Computed:            true,
+		},
+		"comment": schema.StringAttribute{
+			MarkdownDescription: "Comment of the access",
+			Computed:            true,
+		},
+		"force_key": schema.StringAttribute{
+			MarkdownDescription: "SSH key forced for the access",
+			Computed:            true,
+		},
+		"force_password": schema.StringAttribute{
+			MarkdownDescription: "Password forced for the access",
+			Computed:            true,
+		},
+		"added_by": schema.StringAttribute{
+			MarkdownDescription: "The account which added the access",
+			Computed:            true,
+		},
+		"added_date": schema.StringAttribute{
+			MarkdownDescription: "The date the access was added",
+			Computed:            true,
+		},
+		"expiry": schema.Int64Attribute{
+			MarkdownDescription: "Unix timestamp at which the access expires, null if it does not expire",
+			Computed:            true,
+		},
+	}
+}
+
+// flattenAccountAccess converts an ACL entry to its data source model.
+func flattenAccountAccess(accessType string, group *string, acl *bastion.ACL) accountAccessModel {
+	access := accountAccessModel{
+		Type:          types.StringValue(accessType),
+		Group:         types.StringPointerValue(group),
+		IP:            customtypes.NewIPAddressValue(acl.IP),
+		Port:          types.StringValue("*"),
+		User:          types.StringValue("*"),
+		Protocol:      types.StringNull(),
+		ProxyIP:       customtypes.NewIPAddressPointerValue(acl.ProxyIP),
+		ProxyPort:     types.StringNull(),
+		ProxyUser:     types.StringPointerValue(acl.ProxyUser),
+		RemotePort:    types.Int64Null(),
+		Comment:       types.StringPointerValue(acl.UserComment),
+		ForceKey:      types.StringPointerValue(acl.ForceKey),
+		ForcePassword: types.StringPointerValue(acl.ForcePassword),
+		AddedBy:       types.StringValue(acl.AddedBy),
+		AddedDate:     types.StringValue(acl.AddedDate),
+		Expiry:        types.Int64Null(),
+	}
+
+	// API returns null for port and user when set to "*"
+	if acl.Port != nil {
+		access.Port = types.StringValue(acl.Port.ValueString())
+	}
+
+	// For protocol accesses, API returns username as "!protocol"
+	if acl.User != nil && strings.HasPrefix(*acl.User, "!") {
+		access.Protocol = types.StringValue(strings.TrimPrefix(*acl.User, "!"))
+		access.User = types.StringNull()
+	} else if acl.User != nil {
+		access.User = types.StringValue(*acl.User)
+	}
+
+	if acl.ProxyIP != nil {
+		access.ProxyPort = types.StringValue("*")
+		if acl.ProxyPort != nil {
+			access.ProxyPort = types.StringValue(acl.ProxyPort.ValueString())
+		}
+	}
+
+	if acl.RemotePort != nil {
+		access.RemotePort = types.Int64Value(int64(acl.RemotePort.ValueInt()))
+	}
+
+	if acl.Expiry != nil {
+		access.Expiry = types.Int64Value(int64(*acl.Expiry))
+	}
+
+	return access
+}
+
+// aclContainsIP checks if the IP or subnet of an ACL contains the given address or subnet, both compared in the
+// canonical form of The Bastion.
+func aclContainsIP(aclIP string, target netip.Prefix) bool {
+	aclPrefix, err := parseIPOrPrefix(aclIP)
+	if err != nil {
+		return false
+	}
+	return aclPrefix.Bits() <= target.Bits() && aclPrefix.Contains(target.Addr())
+}
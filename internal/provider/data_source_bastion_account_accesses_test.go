@@ -0,0 +1,163 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/adfinis/terraform-provider-bastion/internal/provider/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccAccountAccessesDataSource(t *testing.T) {
+	err := testutils.CreateAccount("testaccesses1")
+	if err != nil {
+		t.Errorf("Unable to create test account: %s", err)
+	}
+
+	t.Cleanup(func() {
+		err := testutils.DeleteAccount("testaccesses1")
+		if err != nil {
+			t.Errorf("Unable to delete test account: %s", err)
+		}
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccountAccessesDataSourceConfig("testaccesses1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bastion_account_accesses.all", "accesses.#", "2"),
+					resource.TestCheckResourceAttr("data.bastion_account_accesses.filtered", "accesses.#", "1"),
+					resource.TestCheckResourceAttr("data.bastion_account_accesses.filtered", "accesses.0.type", "personal"),
+					resource.TestCheckResourceAttr("data.bastion_account_accesses.filtered", "accesses.0.ip", "192.168.20.0/24"),
+					resource.TestCheckResourceAttr("data.bastion_account_accesses.filtered", "accesses.0.port", "22"),
+					resource.TestCheckResourceAttr("data.bastion_account_accesses.filtered", "accesses.0.user", "root"),
+					resource.TestCheckResourceAttr("data.bastion_account_accesses.filtered", "accesses.0.comment", "subnet access"),
+					resource.TestCheckNoResourceAttr("data.bastion_account_accesses.filtered", "accesses.0.group"),
+					resource.TestCheckResourceAttr("data.bastion_account_accesses.none", "accesses.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAccountAccessesDataSourceConfig(account string) string {
+	return providerConfig + fmt.Sprintf(`
+resource "bastion_account_personal_access" "subnet" {
+  account = %[1]q
+  ip      = "192.168.20.0/24"
+  port    = "22"
+  user    = "root"
+  comment = "subnet access"
+}
+
+resource "bastion_account_personal_access" "host" {
+  account = %[1]q
+  ip      = "192.168.30.10"
+  port    = "22"
+  user    = "root"
+}
+
+data "bastion_account_accesses" "all" {
+  account = %[1]q
+  type    = "personal"
+
+  depends_on = [
+    bastion_account_personal_access.subnet,
+    bastion_account_personal_access.host,
+  ]
+}
+
+data "bastion_account_accesses" "filtered" {
+  account = %[1]q
+  ip      = "192.168.20.42"
+
+  depends_on = [
+    bastion_account_personal_access.subnet,
+    bastion_account_personal_access.host,
+  ]
+}
+
+data "bastion_account_accesses" "none" {
+  account = %[1]q
+  group   = "doesnotexist"
+
+  depends_on = [
+    bastion_account_personal_access.subnet,
+    bastion_account_personal_access.host,
+  ]
+}
+`, account)
+}
+
+func TestACLContainsIP(t *testing.T) {
+	testCases := []struct {
+		name     string
+		aclIP    string
+		target   string
+		expected bool
+	}{
+		{
+			name:     "same address",
+			aclIP:    "10.0.0.1",
+			target:   "10.0.0.1",
+			expected: true,
+		},
+		{
+			name:     "leading zeros",
+			aclIP:    "10.0.0.1",
+			target:   "10.00.0.001",
+			expected: true,
+		},
+		{
+			name:     "host prefix",
+			aclIP:    "10.0.0.1",
+			target:   "10.0.0.1/32",
+			expected: true,
+		},
+		{
+			name:     "address within subnet",
+			aclIP:    "10.0.0.0/24",
+			target:   "10.0.0.42",
+			expected: true,
+		},
+		{
+			name:     "subnet within subnet",
+			aclIP:    "10.0.0.0/16",
+			target:   "10.0.1.0/24",
+			expected: true,
+		},
+		{
+			name:     "subnet larger than the ACL",
+			aclIP:    "10.0.1.0/24",
+			target:   "10.0.0.0/16",
+			expected: false,
+		},
+		{
+			name:     "uncompressed IPv6",
+			aclIP:    "2001:db8::1",
+			target:   "2001:0db8:0000:0000:0000:0000:0000:0001",
+			expected: true,
+		},
+		{
+			name:     "different address",
+			aclIP:    "10.0.0.1",
+			target:   "10.0.0.2",
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			target, err := parseIPOrPrefix(tc.target)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, aclContainsIP(tc.aclIP, target))
+		})
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &AccountEgressKeysDataSource{}
+var _ datasource.DataSourceWithConfigure = &AccountEgressKeysDataSource{}
+
+// NewAccountEgressKeysDataSource is a helper function to simplify the provider implementation.
+func NewAccountEgressKeysDataSource() datasource.DataSource {
+	return &AccountEgressKeysDataSource{}
+}
+
+// AccountEgressKeysDataSource is the data source implementation.
+type AccountEgressKeysDataSource struct {
+	client *bastion.Client
+}
+
+// accountEgressKeysDataSourceModel describes the data source data model.
+type accountEgressKeysDataSourceModel struct {
+	Account types.String     `tfsdk:"account"`
+	Keys    []egressKeyModel `tfsdk:"keys"`
+}
+
+// egressKeyModel describes a single egress key.
+type egressKeyModel struct {
+	Fingerprint types.String `tfsdk:"fingerprint"`
+	Typecode    types.String `tfsdk:"typecode"`
+	Family      types.String `tfsdk:"family"`
+	Size        types.Int64  `tfsdk:"size"`
+	Comment     types.String `tfsdk:"comment"`
+	Line        types.String `tfsdk:"line"`
+	FromList    types.List   `tfsdk:"from_list"`
+}
+
+// Metadata returns the data source type name.
+func (d *AccountEgressKeysDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_account_egress_keys"
+}
+
+// Schema defines the schema for the data source.
+func (d *AccountEgressKeysDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the egress public keys of a Bastion account. These keys must be present in the `authorized_keys` of the servers the account connects to with personal accesses.",
+		Attributes: map[string]schema.Attribute{
+			"account": schema.StringAttribute{
+				MarkdownDescription: "The name of the Bastion account",
+				Required:            true,
+			},
+			"keys": schema.ListNestedAttribute{
+				MarkdownDescription: "The egress public keys of the account",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"fingerprint": schema.StringAttribute{
+							MarkdownDescription: "The fingerprint of the key",
+							Computed:            true,
+						},
+						"typecode": schema.StringAttribute{
+							MarkdownDescription: "The SSH type of the key, e.g. `ssh-ed25519`",
+							Computed:            true,
+						},
+						"family": schema.StringAttribute{
+							MarkdownDescription: "The algorithm family of the key",
+							Computed:            true,
+						},
+						"size": schema.Int64Attribute{
+							MarkdownDescription: "The size of the key in bits",
+							Computed:            true,
+						},
+						"comment": schema.StringAttribute{
+							MarkdownDescription: "The comment of the key",
+							Computed:            true,
+						},
+						"line": schema.StringAttribute{
+							MarkdownDescription: "The public key in OpenSSH format",
+							Computed:            true,
+						},
+						"from_list": schema.ListAttribute{
+							ElementType:         types.StringType,
+							MarkdownDescription: "The IPs or subnets the key is expected to connect from",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the bastion client to the data source.
+func (d *AccountEgressKeysDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*bastion.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *bastion.Client type for data source configuration.",
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *AccountEgressKeysDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data accountEgressKeysDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys, err := d.client.AccountListEgressKeys(data.Account.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Bastion Account Egress Keys",
+			err.Error(),
+		)
+		return
+	}
+
+	data.Keys = make([]egressKeyModel, 0, len(keys))
+	for _, key := range keys {
+		fromList, diags := types.ListValueFrom(ctx, types.StringType, key.FromList)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		data.Keys = append(data.Keys, egressKeyModel{
+			Fingerprint: types.StringValue(key.Fingerprint),
+			Typecode:    types.StringValue(key.Typecode),
+			Family:      types.StringValue(key.Family),
+			Size:        types.Int64Value(int64(key.Size)),
+			Comment:     types.StringValue(key.Comment),
+			Line:        types.StringValue(key.Line),
+			FromList:    fromList,
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,53 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/adfinis/terraform-provider-bastion/internal/provider/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAccountEgressKeysDataSource(t *testing.T) {
+	err := testutils.CreateAccount("testegresskeys1")
+	if err != nil {
+		t.Errorf("Unable to create test account: %s", err)
+	}
+
+	t.Cleanup(func() {
+		err := testutils.DeleteAccount("testegresskeys1")
+		if err != nil {
+			t.Errorf("Unable to delete test account: %s", err)
+		}
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccountEgressKeysDataSourceConfig("testegresskeys1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bastion_account_egress_keys.test", "account", "testegresskeys1"),
+					// accounts are created with one egress key
+					resource.TestCheckResourceAttr("data.bastion_account_egress_keys.test", "keys.#", "1"),
+					resource.TestCheckResourceAttrSet("data.bastion_account_egress_keys.test", "keys.0.fingerprint"),
+					resource.TestCheckResourceAttrSet("data.bastion_account_egress_keys.test", "keys.0.typecode"),
+					resource.TestCheckResourceAttrSet("data.bastion_account_egress_keys.test", "keys.0.line"),
+					resource.TestCheckResourceAttrSet("data.bastion_account_egress_keys.test", "keys.0.from_list.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAccountEgressKeysDataSourceConfig(account string) string {
+	return providerConfig + fmt.Sprintf(`
+data "bastion_account_egress_keys" "test" {
+  account = %[1]q
+}
+`, account)
+}
@@ -0,0 +1,55 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAccountDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create an account first, then read it with the data source
+			{
+				Config: testAccAccountDataSourceConfig("testaccount-ds"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bastion_account.test", "account", "testaccount-ds"),
+					resource.TestCheckResourceAttr("data.bastion_account.test", "is_active", "true"),
+					resource.TestCheckResourceAttr("data.bastion_account.test", "is_frozen", "false"),
+					resource.TestCheckResourceAttr("data.bastion_account.test", "is_expired", "false"),
+					resource.TestCheckResourceAttr("data.bastion_account.test", "is_admin", "false"),
+					resource.TestCheckResourceAttr("data.bastion_account.test", "osh_only", "true"),
+					resource.TestCheckResourceAttr("data.bastion_account.test", "max_inactive_days", "30"),
+					resource.TestCheckResourceAttr("data.bastion_account.test", "creation_information.by", "bastionadmin"),
+					resource.TestCheckResourceAttr("data.bastion_account.test", "creation_information.comment", "data source test"),
+					resource.TestCheckResourceAttrSet("data.bastion_account.test", "creation_information.timestamp"),
+					resource.TestCheckResourceAttrSet("data.bastion_account.test", "ingress_piv_grace.enabled"),
+					resource.TestCheckResourceAttrSet("data.bastion_account.test", "allowed_commands.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAccountDataSourceConfig(account string) string {
+	return providerConfig + fmt.Sprintf(`
+resource "bastion_account" "test" {
+  account           = %[1]q
+  uid_auto          = true
+  no_key            = true
+  osh_only          = true
+  max_inactive_days = 30
+  comment           = "data source test"
+}
+
+data "bastion_account" "test" {
+  account = bastion_account.test.account
+}
+`, account)
+}
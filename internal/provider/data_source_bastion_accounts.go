@@ -0,0 +1,283 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"regexp"
+	"slices"
+
+	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &AccountsDataSource{}
+var _ datasource.DataSourceWithConfigure = &AccountsDataSource{}
+
+// NewAccountsDataSource is a helper function to simplify the provider implementation.
+func NewAccountsDataSource() datasource.DataSource {
+	return &AccountsDataSource{}
+}
+
+// AccountsDataSource is the data source implementation.
+type AccountsDataSource struct {
+	client *bastion.Client
+}
+
+// accountsDataSourceModel describes the data source data model.
+type accountsDataSourceModel struct {
+	NamePrefix   types.String        `tfsdk:"name_prefix"`
+	NameRegex    types.String        `tfsdk:"name_regex"`
+	IsActive     types.Bool          `tfsdk:"is_active"`
+	IsExpired    types.Bool          `tfsdk:"is_expired"`
+	IsFrozen     types.Bool          `tfsdk:"is_frozen"`
+	IsAdmin      types.Bool          `tfsdk:"is_admin"`
+	IsAuditor    types.Bool          `tfsdk:"is_auditor"`
+	IsSuperOwner types.Bool          `tfsdk:"is_super_owner"`
+	Group        types.String        `tfsdk:"group"`
+	Names        types.List          `tfsdk:"names"`
+	Accounts     []accountsItemModel `tfsdk:"accounts"`
+}
+
+// accountsItemModel describes a single account of the list.
+type accountsItemModel struct {
+	Account      types.String `tfsdk:"account"`
+	IsActive     types.Bool   `tfsdk:"is_active"`
+	IsExpired    types.Bool   `tfsdk:"is_expired"`
+	IsFrozen     types.Bool   `tfsdk:"is_frozen"`
+	IsAdmin      types.Bool   `tfsdk:"is_admin"`
+	IsAuditor    types.Bool   `tfsdk:"is_auditor"`
+	IsSuperOwner types.Bool   `tfsdk:"is_super_owner"`
+	AlwaysActive types.Bool   `tfsdk:"always_active"`
+	OshOnly      types.Bool   `tfsdk:"osh_only"`
+	CanConnect   types.Bool   `tfsdk:"can_connect"`
+}
+
+// Metadata returns the data source type name.
+func (d *AccountsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_accounts"
+}
+
+// Schema defines the schema for the data source.
+func (d *AccountsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the Bastion accounts. All filters are optional and combined with a logical AND.",
+		Attributes: map[string]schema.Attribute{
+			"name_prefix": schema.StringAttribute{
+				MarkdownDescription: "Only list the accounts whose name starts with this prefix",
+				Optional:            true,
+			},
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Only list the accounts whose name matches this regular expression",
+				Optional:            true,
+			},
+			"is_active": schema.BoolAttribute{
+				MarkdownDescription: "Only list the accounts which are active (`true`) or inactive (`false`)",
+				Optional:            true,
+			},
+			"is_expired": schema.BoolAttribute{
+				MarkdownDescription: "Only list the accounts which are expired (`true`) or not expired (`false`)",
+				Optional:            true,
+			},
+			"is_frozen": schema.BoolAttribute{
+				MarkdownDescription: "Only list the accounts which are frozen (`true`) or not frozen (`false`)",
+				Optional:            true,
+			},
+			"is_admin": schema.BoolAttribute{
+				MarkdownDescription: "Only list the accounts which are (`true`) or are not (`false`) Bastion admins",
+				Optional:            true,
+			},
+			"is_auditor": schema.BoolAttribute{
+				MarkdownDescription: "Only list the accounts which are (`true`) or are not (`false`) auditors",
+				Optional:            true,
+			},
+			"is_super_owner": schema.BoolAttribute{
+				MarkdownDescription: "Only list the accounts which are (`true`) or are not (`false`) super owners",
+				Optional:            true,
+			},
+			"group": schema.StringAttribute{
+				MarkdownDescription: "Only list the accounts which are members of this Bastion group",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"names": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The names of the matching accounts, sorted alphabetically",
+				Computed:            true,
+			},
+			"accounts": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching accounts, sorted alphabetically",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"account": schema.StringAttribute{
+							MarkdownDescription: "The name of the account",
+							Computed:            true,
+						},
+						"is_active": schema.BoolAttribute{
+							MarkdownDescription: "Whether the account is active",
+							Computed:            true,
+						},
+						"is_expired": schema.BoolAttribute{
+							MarkdownDescription: "Whether the account is expired because of inactivity",
+							Computed:            true,
+						},
+						"is_frozen": schema.BoolAttribute{
+							MarkdownDescription: "Whether the account is frozen",
+							Computed:            true,
+						},
+						"is_admin": schema.BoolAttribute{
+							MarkdownDescription: "Whether the account is a Bastion admin",
+							Computed:            true,
+						},
+						"is_auditor": schema.BoolAttribute{
+							MarkdownDescription: "Whether the account is an auditor",
+							Computed:            true,
+						},
+						"is_super_owner": schema.BoolAttribute{
+							MarkdownDescription: "Whether the account is a super owner",
+							Computed:            true,
+						},
+						"always_active": schema.BoolAttribute{
+							MarkdownDescription: "Whether the account is always active",
+							Computed:            true,
+						},
+						"osh_only": schema.BoolAttribute{
+							MarkdownDescription: "Whether the account can only use osh (bastion) commands",
+							Computed:            true,
+						},
+						"can_connect": schema.BoolAttribute{
+							MarkdownDescription: "Whether the account is currently allowed to connect to The Bastion",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the bastion client to the data source.
+func (d *AccountsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*bastion.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *bastion.Client type for data source configuration.",
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *AccountsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data accountsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	listOpts := &bastion.AccountListOptions{
+		Audit: true,
+	}
+	if !data.NamePrefix.IsNull() {
+		listOpts.Include = []string{data.NamePrefix.ValueString() + "*"}
+	}
+
+	accounts, err := d.client.AccountList(listOpts)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to List Bastion Accounts",
+			err.Error(),
+		)
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !data.NameRegex.IsNull() {
+		nameRegex, err = regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"Invalid Regular Expression",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	var members []string
+	if !data.Group.IsNull() {
+		group, err := d.client.GroupInfo(data.Group.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Bastion Group",
+				err.Error(),
+			)
+			return
+		}
+		members = group.Members
+	}
+
+	names := []string{}
+	data.Accounts = []accountsItemModel{}
+	for _, account := range accounts {
+		if nameRegex != nil && !nameRegex.MatchString(account.Account) {
+			continue
+		}
+		if !data.Group.IsNull() && !slices.Contains(members, account.Account) {
+			continue
+		}
+		if !matchesBoolFilter(data.IsActive, account.IsActive.Bool()) ||
+			!matchesBoolFilter(data.IsExpired, account.IsExpired.Bool()) ||
+			!matchesBoolFilter(data.IsFrozen, account.IsFrozen.Bool()) ||
+			!matchesBoolFilter(data.IsAdmin, account.IsAdmin.Bool()) ||
+			!matchesBoolFilter(data.IsAuditor, account.IsAuditor.Bool()) ||
+			!matchesBoolFilter(data.IsSuperOwner, account.IsSuperOwner.Bool()) {
+			continue
+		}
+
+		names = append(names, account.Account)
+		data.Accounts = append(data.Accounts, accountsItemModel{
+			Account:      types.StringValue(account.Account),
+			IsActive:     types.BoolValue(account.IsActive.Bool()),
+			IsExpired:    types.BoolValue(account.IsExpired.Bool()),
+			IsFrozen:     types.BoolValue(account.IsFrozen.Bool()),
+			IsAdmin:      types.BoolValue(account.IsAdmin.Bool()),
+			IsAuditor:    types.BoolValue(account.IsAuditor.Bool()),
+			IsSuperOwner: types.BoolValue(account.IsSuperOwner.Bool()),
+			AlwaysActive: types.BoolValue(account.AlwaysActive.Bool()),
+			OshOnly:      types.BoolValue(account.OshOnly.Bool()),
+			CanConnect:   types.BoolValue(account.CanConnect.Bool()),
+		})
+	}
+
+	namesList, diags := types.ListValueFrom(ctx, types.StringType, names)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Names = namesList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// matchesBoolFilter returns true if the filter is not set or equals the value.
+func matchesBoolFilter(filter types.Bool, value bool) bool {
+	return filter.IsNull() || filter.ValueBool() == value
+}
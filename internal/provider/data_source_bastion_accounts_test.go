@@ -0,0 +1,95 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/adfinis/terraform-provider-bastion/internal/provider/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAccountsDataSource(t *testing.T) {
+	err := testutils.CreateAccounts("testaccountsds1", "testaccountsds2", "testaccountsds3")
+	if err != nil {
+		t.Errorf("Unable to create test accounts: %s", err)
+	}
+
+	err = testutils.CreateGroup("testaccountsdsgrp", "bastionadmin", bastion.ED25519)
+	if err != nil {
+		t.Errorf("Unable to create test group: %s", err)
+	}
+
+	err = testutils.TestBastionClient.GroupAddMember("testaccountsdsgrp", "testaccountsds2")
+	if err != nil {
+		t.Errorf("Unable to add test group member: %s", err)
+	}
+
+	t.Cleanup(func() {
+		err := testutils.DeleteGroup("testaccountsdsgrp")
+		if err != nil {
+			t.Errorf("Unable to delete test group: %s", err)
+		}
+		err = testutils.DeleteAccounts("testaccountsds1", "testaccountsds2", "testaccountsds3")
+		if err != nil {
+			t.Errorf("Unable to delete test accounts: %s", err)
+		}
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Filter by prefix
+			{
+				Config: testAccAccountsDataSourceConfig(`name_prefix = "testaccountsds"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bastion_accounts.test", "names.#", "3"),
+					resource.TestCheckResourceAttr("data.bastion_accounts.test", "names.0", "testaccountsds1"),
+					resource.TestCheckResourceAttr("data.bastion_accounts.test", "accounts.#", "3"),
+					resource.TestCheckResourceAttr("data.bastion_accounts.test", "accounts.0.account", "testaccountsds1"),
+					resource.TestCheckResourceAttr("data.bastion_accounts.test", "accounts.0.is_frozen", "false"),
+				),
+			},
+			// Filter by regex
+			{
+				Config: testAccAccountsDataSourceConfig(`name_regex = "^testaccountsds[13]$"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bastion_accounts.test", "names.#", "2"),
+					resource.TestCheckResourceAttr("data.bastion_accounts.test", "names.0", "testaccountsds1"),
+					resource.TestCheckResourceAttr("data.bastion_accounts.test", "names.1", "testaccountsds3"),
+				),
+			},
+			// Filter by group membership
+			{
+				Config: testAccAccountsDataSourceConfig(`
+  name_prefix = "testaccountsds"
+  group       = "testaccountsdsgrp"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bastion_accounts.test", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.bastion_accounts.test", "names.0", "testaccountsds2"),
+				),
+			},
+			// Filter by flags
+			{
+				Config: testAccAccountsDataSourceConfig(`
+  name_prefix = "testaccountsds"
+  is_admin    = true`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bastion_accounts.test", "names.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAccountsDataSourceConfig(filters string) string {
+	return providerConfig + fmt.Sprintf(`
+data "bastion_accounts" "test" {
+  %s
+}
+`, filters)
+}
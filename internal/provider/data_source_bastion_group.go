@@ -5,8 +5,10 @@ package provider
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/adfinis/terraform-provider-bastion/internal/provider/customtypes"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -27,11 +29,29 @@ type GroupDataSource struct {
 
 // groupDataSourceModel describes the data source data model.
 type groupDataSourceModel struct {
-	Group       types.String `tfsdk:"group"`
-	Owners      types.List   `tfsdk:"owners"`
-	Members     types.List   `tfsdk:"members"`
-	Gatekeepers types.List   `tfsdk:"gatekeepers"`
-	ACLKeepers  types.List   `tfsdk:"aclkeepers"`
+	Group                types.String              `tfsdk:"group"`
+	IncludeServers       types.Bool                `tfsdk:"include_servers"`
+	IncludeGuestAccesses types.Bool                `tfsdk:"include_guest_accesses"`
+	Owners               types.List                `tfsdk:"owners"`
+	Members              types.List                `tfsdk:"members"`
+	Gatekeepers          types.List                `tfsdk:"gatekeepers"`
+	ACLKeepers           types.List                `tfsdk:"aclkeepers"`
+	Guests               types.List                `tfsdk:"guests"`
+	Inactive             types.List                `tfsdk:"inactive"`
+	Keys                 []groupKeyModel           `tfsdk:"keys"`
+	MFARequired          types.String              `tfsdk:"mfa_required"`
+	IdleLockTimeout      customtypes.Duration      `tfsdk:"idle_lock_timeout"`
+	IdleKillTimeout      customtypes.Duration      `tfsdk:"idle_kill_timeout"`
+	GuestTtlLimit        customtypes.Duration      `tfsdk:"guest_ttl_limit"`
+	TryPersonalKeys      types.Bool                `tfsdk:"try_personal_keys"`
+	Servers              []accountAccessModel      `tfsdk:"servers"`
+	GuestAccesses        []groupGuestAccessesModel `tfsdk:"guest_accesses"`
+}
+
+// groupGuestAccessesModel describes the accesses of a single guest of the group.
+type groupGuestAccessesModel struct {
+	Account  types.String         `tfsdk:"account"`
+	Accesses []accountAccessModel `tfsdk:"accesses"`
 }
 
 // Metadata returns the data source type name.
@@ -47,6 +67,14 @@ func (d *GroupDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 				MarkdownDescription: "The name of the Bastion group",
 				Required:            true,
 			},
+			"include_servers": schema.BoolAttribute{
+				MarkdownDescription: "Whether to list the server accesses of the group in `servers`. Defaults to false.",
+				Optional:            true,
+			},
+			"include_guest_accesses": schema.BoolAttribute{
+				MarkdownDescription: "Whether to list the accesses of each guest in `guest_accesses`. Defaults to false.",
+				Optional:            true,
+			},
 			"owners": schema.ListAttribute{
 				ElementType:         types.StringType,
 				MarkdownDescription: "The owners of the Bastion group",
@@ -67,6 +95,72 @@ func (d *GroupDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 				MarkdownDescription: "The ACL keepers of the Bastion group",
 				Computed:            true,
 			},
+			"guests": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The guests of the Bastion group",
+				Computed:            true,
+			},
+			"inactive": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The members and guests of the Bastion group whose account is inactive",
+				Computed:            true,
+			},
+			"keys": schema.ListNestedAttribute{
+				MarkdownDescription: "The egress public keys of the Bastion group, oldest first",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: groupKeyAttributes(),
+				},
+			},
+			"mfa_required": schema.StringAttribute{
+				MarkdownDescription: "The MFA policy of the Bastion group, null if not set",
+				Computed:            true,
+			},
+			"idle_lock_timeout": schema.StringAttribute{
+				CustomType:          customtypes.DurationType{},
+				MarkdownDescription: "The idle lock timeout as a duration like `1h30m`, null if not set",
+				Computed:            true,
+			},
+			"idle_kill_timeout": schema.StringAttribute{
+				CustomType:          customtypes.DurationType{},
+				MarkdownDescription: "The idle kill timeout as a duration like `1h30m`, null if not set",
+				Computed:            true,
+			},
+			"guest_ttl_limit": schema.StringAttribute{
+				CustomType:          customtypes.DurationType{},
+				MarkdownDescription: "The maximum TTL of guest accesses as a duration like `7d`, null if not set",
+				Computed:            true,
+			},
+			"try_personal_keys": schema.BoolAttribute{
+				MarkdownDescription: "Whether the personal keys of the members are tried when connecting to the group servers, null if not set",
+				Computed:            true,
+			},
+			"servers": schema.ListNestedAttribute{
+				MarkdownDescription: "The server accesses of the Bastion group. Only set if `include_servers` is true.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: accountAccessAttributes(),
+				},
+			},
+			"guest_accesses": schema.ListNestedAttribute{
+				MarkdownDescription: "The accesses of each guest of the Bastion group. Only set if `include_guest_accesses` is true.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"account": schema.StringAttribute{
+							MarkdownDescription: "The name of the guest account",
+							Computed:            true,
+						},
+						"accesses": schema.ListNestedAttribute{
+							MarkdownDescription: "The accesses of the guest",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: accountAccessAttributes(),
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -135,5 +229,82 @@ func (d *GroupDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	}
 	data.ACLKeepers = aclkeepers
 
+	guests, diags := types.ListValueFrom(ctx, types.StringType, group.Guests)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Guests = guests
+
+	inactive, diags := types.ListValueFrom(ctx, types.StringType, group.Inactive)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Inactive = inactive
+
+	data.Keys = []groupKeyModel{}
+	for _, key := range group.SortedKeys() {
+		model, diags := flattenGroupKey(ctx, &key)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		data.Keys = append(data.Keys, model)
+	}
+
+	data.MFARequired = types.StringNull()
+	if group.MFARequired != nil {
+		data.MFARequired = types.StringValue(string(*group.MFARequired))
+	}
+
+	data.TryPersonalKeys = types.BoolNull()
+	if group.TryPersonalKeys != nil {
+		data.TryPersonalKeys = types.BoolValue(group.TryPersonalKeys.Bool())
+	}
+
+	data.IdleLockTimeout = customtypes.NewDurationPointerValue(group.IdleLockTimeout)
+	data.IdleKillTimeout = customtypes.NewDurationPointerValue(group.IdleKillTimeout)
+	data.GuestTtlLimit = customtypes.NewDurationPointerValue(group.GuestTtlLimit)
+
+	if data.IncludeServers.ValueBool() {
+		servers, err := d.client.GroupListServers(data.Group.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Bastion Group Servers",
+				err.Error(),
+			)
+			return
+		}
+
+		data.Servers = []accountAccessModel{}
+		for _, server := range servers {
+			data.Servers = append(data.Servers, flattenAccountAccess("group", data.Group.ValueStringPointer(), (*bastion.ACL)(server)))
+		}
+	}
+
+	if data.IncludeGuestAccesses.ValueBool() {
+		data.GuestAccesses = []groupGuestAccessesModel{}
+		for _, guest := range group.Guests {
+			accesses, err := d.client.GroupListGuestAccesses(data.Group.ValueString(), guest)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Unable to Read Bastion Group Guest Accesses",
+					fmt.Sprintf("Could not list the accesses of guest %s: %s", guest, err.Error()),
+				)
+				return
+			}
+
+			model := groupGuestAccessesModel{
+				Account:  types.StringValue(guest),
+				Accesses: []accountAccessModel{},
+			}
+			for _, access := range accesses {
+				model.Accesses = append(model.Accesses, flattenAccountAccess("group-guest", data.Group.ValueStringPointer(), (*bastion.ACL)(access)))
+			}
+			data.GuestAccesses = append(data.GuestAccesses, model)
+		}
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -0,0 +1,191 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &GroupKeysDataSource{}
+var _ datasource.DataSourceWithConfigure = &GroupKeysDataSource{}
+
+// NewGroupKeysDataSource is a helper function to simplify the provider implementation.
+func NewGroupKeysDataSource() datasource.DataSource {
+	return &GroupKeysDataSource{}
+}
+
+// GroupKeysDataSource is the data source implementation.
+type GroupKeysDataSource struct {
+	client *bastion.Client
+}
+
+// groupKeysDataSourceModel describes the data source data model.
+type groupKeysDataSourceModel struct {
+	Group          types.String    `tfsdk:"group"`
+	Keys           []groupKeyModel `tfsdk:"keys"`
+	AuthorizedKeys types.String    `tfsdk:"authorized_keys"`
+}
+
+// groupKeyModel describes a single egress key of a group.
+type groupKeyModel struct {
+	Fingerprint        types.String `tfsdk:"fingerprint"`
+	Typecode           types.String `tfsdk:"typecode"`
+	Family             types.String `tfsdk:"family"`
+	Size               types.Int64  `tfsdk:"size"`
+	Mtime              types.Int64  `tfsdk:"mtime"`
+	Comment            types.String `tfsdk:"comment"`
+	Line               types.String `tfsdk:"line"`
+	FromList           types.List   `tfsdk:"from_list"`
+	AuthorizedKeysLine types.String `tfsdk:"authorized_keys_line"`
+}
+
+// Metadata returns the data source type name.
+func (d *GroupKeysDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_keys"
+}
+
+// Schema defines the schema for the data source.
+func (d *GroupKeysDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the egress public keys of a Bastion group. These keys must be present in the `authorized_keys` of the servers the group members connect to.",
+		Attributes: map[string]schema.Attribute{
+			"group": schema.StringAttribute{
+				MarkdownDescription: "The name of the Bastion group",
+				Required:            true,
+			},
+			"keys": schema.ListNestedAttribute{
+				MarkdownDescription: "The egress public keys of the group, oldest first",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: groupKeyAttributes(),
+				},
+			},
+			"authorized_keys": schema.StringAttribute{
+				MarkdownDescription: "The `authorized_keys_line` of all keys, one per line",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure adds the bastion client to the data source.
+func (d *GroupKeysDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*bastion.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *bastion.Client type for data source configuration.",
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *GroupKeysDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data groupKeysDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group, err := d.client.GroupInfo(data.Group.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Bastion Group",
+			err.Error(),
+		)
+		return
+	}
+
+	keys := group.SortedKeys()
+	lines := make([]string, 0, len(keys))
+	data.Keys = make([]groupKeyModel, 0, len(keys))
+	for _, key := range keys {
+		model, diags := flattenGroupKey(ctx, &key)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		lines = append(lines, model.AuthorizedKeysLine.ValueString()+"\n")
+		data.Keys = append(data.Keys, model)
+	}
+	data.AuthorizedKeys = types.StringValue(strings.Join(lines, ""))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// groupKeyAttributes returns the schema attributes of a group key.
+func groupKeyAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"fingerprint": schema.StringAttribute{
+			MarkdownDescription: "The fingerprint of the key",
+			Computed:            true,
+		},
+		"typecode": schema.StringAttribute{
+			MarkdownDescription: "The SSH type of the key, e.g. `ssh-ed25519`",
+			Computed:            true,
+		},
+		"family": schema.StringAttribute{
+			MarkdownDescription: "The algorithm family of the key",
+			Computed:            true,
+		},
+		"size": schema.Int64Attribute{
+			MarkdownDescription: "The size of the key in bits",
+			Computed:            true,
+		},
+		"mtime": schema.Int64Attribute{
+			MarkdownDescription: "Unix timestamp of the key creation",
+			Computed:            true,
+		},
+		"comment": schema.StringAttribute{
+			MarkdownDescription: "The comment of the key",
+			Computed:            true,
+		},
+		"line": schema.StringAttribute{
+			MarkdownDescription: "The public key in OpenSSH format",
+			Computed:            true,
+		},
+		"from_list": schema.ListAttribute{
+			ElementType:         types.StringType,
+			MarkdownDescription: "The IPs or subnets the key is expected to connect from",
+			Computed:            true,
+		},
+		"authorized_keys_line": schema.StringAttribute{
+			MarkdownDescription: "The public key line for an `authorized_keys` file, restricted to `from_list` with a `from=\"...\"` option",
+			Computed:            true,
+		},
+	}
+}
+
+// flattenGroupKey converts a group key to its data source model.
+func flattenGroupKey(ctx context.Context, key *bastion.Key) (groupKeyModel, diag.Diagnostics) {
+	fromList, diags := types.ListValueFrom(ctx, types.StringType, key.FromList)
+
+	return groupKeyModel{
+		Fingerprint:        types.StringValue(key.Fingerprint),
+		Typecode:           types.StringValue(key.Typecode),
+		Family:             types.StringValue(key.Family),
+		Size:               types.Int64Value(int64(key.Size)),
+		Mtime:              types.Int64Value(int64(key.Mtime)),
+		Comment:            types.StringValue(key.Comment),
+		Line:               types.StringValue(key.Line),
+		FromList:           fromList,
+		AuthorizedKeysLine: types.StringValue(key.AuthorizedKeysLine()),
+	}, diags
+}
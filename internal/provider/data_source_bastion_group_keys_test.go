@@ -0,0 +1,62 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/adfinis/terraform-provider-bastion/internal/provider/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccGroupKeysDataSource(t *testing.T) {
+	err := testutils.CreateGroup("testgroupkeys1", "bastionadmin", bastion.ED25519)
+	if err != nil {
+		t.Errorf("Unable to create test group: %s", err)
+	}
+
+	t.Cleanup(func() {
+		err := testutils.DeleteGroup("testgroupkeys1")
+		if err != nil {
+			t.Errorf("Unable to delete test group: %s", err)
+		}
+	})
+
+	fingerprint, err := testutils.GetGroupKeyFingerprint("testgroupkeys1")
+	if err != nil {
+		t.Errorf("Unable to get group key fingerprint: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGroupKeysDataSourceConfig("testgroupkeys1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bastion_group_keys.test", "group", "testgroupkeys1"),
+					// groups are created with one egress key
+					resource.TestCheckResourceAttr("data.bastion_group_keys.test", "keys.#", "1"),
+					resource.TestCheckResourceAttr("data.bastion_group_keys.test", "keys.0.fingerprint", fingerprint),
+					resource.TestCheckResourceAttr("data.bastion_group_keys.test", "keys.0.typecode", "ssh-ed25519"),
+					resource.TestCheckResourceAttrSet("data.bastion_group_keys.test", "keys.0.mtime"),
+					resource.TestCheckResourceAttrSet("data.bastion_group_keys.test", "keys.0.from_list.#"),
+					resource.TestMatchResourceAttr("data.bastion_group_keys.test", "keys.0.authorized_keys_line", regexp.MustCompile(`^from="[^"]+" ssh-ed25519 `)),
+					resource.TestMatchResourceAttr("data.bastion_group_keys.test", "authorized_keys", regexp.MustCompile(`^from="[^"]+" ssh-ed25519 .*\n$`)),
+				),
+			},
+		},
+	})
+}
+
+func testAccGroupKeysDataSourceConfig(group string) string {
+	return providerConfig + fmt.Sprintf(`
+data "bastion_group_keys" "test" {
+  group = %[1]q
+}
+`, group)
+}
@@ -0,0 +1,305 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/netip"
+	"strings"
+
+	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/adfinis/terraform-provider-bastion/internal/provider/customtypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &GroupServersDataSource{}
+var _ datasource.DataSourceWithConfigure = &GroupServersDataSource{}
+
+// NewGroupServersDataSource is a helper function to simplify the provider implementation.
+func NewGroupServersDataSource() datasource.DataSource {
+	return &GroupServersDataSource{}
+}
+
+// GroupServersDataSource is the data source implementation.
+type GroupServersDataSource struct {
+	client *bastion.Client
+}
+
+// groupServersDataSourceModel describes the data source data model.
+type groupServersDataSourceModel struct {
+	Group    types.String          `tfsdk:"group"`
+	IPPrefix customtypes.IPAddress `tfsdk:"ip_prefix"`
+	Port     types.String          `tfsdk:"port"`
+	Protocol types.String          `tfsdk:"protocol"`
+	Servers  []groupServerModel    `tfsdk:"servers"`
+}
+
+// groupServerModel describes a single server access of the group.
+type groupServerModel struct {
+	ImportID      types.String          `tfsdk:"import_id"`
+	IP            customtypes.IPAddress `tfsdk:"ip"`
+	Port          types.String          `tfsdk:"port"`
+	User          types.String          `tfsdk:"user"`
+	Protocol      types.String          `tfsdk:"protocol"`
+	ProxyIP       customtypes.IPAddress `tfsdk:"proxy_ip"`
+	ProxyPort     types.String          `tfsdk:"proxy_port"`
+	ProxyUser     types.String          `tfsdk:"proxy_user"`
+	RemotePort    types.Int64           `tfsdk:"remote_port"`
+	Comment       types.String          `tfsdk:"comment"`
+	ForceKey      types.String          `tfsdk:"force_key"`
+	ForcePassword types.String          `tfsdk:"force_password"`
+	ReverseDNS    types.String          `tfsdk:"reverse_dns"`
+	AddedBy       types.String          `tfsdk:"added_by"`
+	AddedDate     types.String          `tfsdk:"added_date"`
+	Expiry        types.Int64           `tfsdk:"expiry"`
+}
+
+// Metadata returns the data source type name.
+func (d *GroupServersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_servers"
+}
+
+// Schema defines the schema for the data source.
+func (d *GroupServersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the server accesses of a Bastion group. All filters are optional and combined with a logical AND.",
+		Attributes: map[string]schema.Attribute{
+			"group": schema.StringAttribute{
+				MarkdownDescription: "The name of the Bastion group",
+				Required:            true,
+			},
+			"ip_prefix": schema.StringAttribute{
+				CustomType:          customtypes.IPAddressType{},
+				MarkdownDescription: "Only list the accesses to IPs or subnets within this prefix, e.g. `10.0.0.0/8`",
+				Optional:            true,
+			},
+			"port": schema.StringAttribute{
+				MarkdownDescription: "Only list the accesses to this port, use '*' for the accesses to all ports",
+				Optional:            true,
+			},
+			"protocol": schema.StringAttribute{
+				MarkdownDescription: "Only list the accesses for this protocol. Valid values are 'ssh', 'sftp', 'scpupload', 'scpdownload', 'rsync', 'portforward'.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("ssh", "sftp", "scpupload", "scpdownload", "rsync", "portforward"),
+				},
+			},
+			"servers": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching server accesses of the group",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"import_id": schema.StringAttribute{
+							MarkdownDescription: "The ID to import the access as a `bastion_group_server` resource",
+							Computed:            true,
+						},
+						"ip": schema.StringAttribute{
+							CustomType:          customtypes.IPAddressType{},
+							MarkdownDescription: "IP or subnet of the access target",
+							Computed:            true,
+						},
+						"port": schema.StringAttribute{
+							MarkdownDescription: "Port of the access target, '*' for all ports",
+							Computed:            true,
+						},
+						"user": schema.StringAttribute{
+							MarkdownDescription: "Username for the access, '*' for all users. Null for protocol accesses.",
+							Computed:            true,
+						},
+						"protocol": schema.StringAttribute{
+							MarkdownDescription: "Protocol of the access, null for ssh accesses",
+							Computed:            true,
+						},
+						"proxy_ip": schema.StringAttribute{
+							CustomType:          customtypes.IPAddressType{},
+							MarkdownDescription: "IP of the proxy server",
+							Computed:            true,
+						},
+						"proxy_port": schema.StringAttribute{
+							MarkdownDescription: "Port of the proxy server",
+							Computed:            true,
+						},
+						"proxy_user": schema.StringAttribute{
+							MarkdownDescription: "Username for the proxy server",
+							Computed:            true,
+						},
+						"remote_port": schema.Int64Attribute{
+							MarkdownDescription: "Remote port forwarded from the target server to The Bastion",
+							Computed:            true,
+						},
+						"comment": schema.StringAttribute{
+							MarkdownDescription: "Comment of the access",
+							Computed:            true,
+						},
+						"force_key": schema.StringAttribute{
+							MarkdownDescription: "SSH key forced for the access",
+							Computed:            true,
+						},
+						"force_password": schema.StringAttribute{
+							MarkdownDescription: "Password forced for the access",
+							Computed:            true,
+						},
+						"reverse_dns": schema.StringAttribute{
+							MarkdownDescription: "Reverse DNS of the access target",
+							Computed:            true,
+						},
+						"added_by": schema.StringAttribute{
+							MarkdownDescription: "The account which added the access",
+							Computed:            true,
+						},
+						"added_date": schema.StringAttribute{
+							MarkdownDescription: "The date the access was added",
+							Computed:            true,
+						},
+						"expiry": schema.Int64Attribute{
+							MarkdownDescription: "Unix timestamp at which the access expires, null if it does not expire",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the bastion client to the data source.
+func (d *GroupServersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*bastion.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *bastion.Client type for data source configuration.",
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *GroupServersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data groupServersDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var ipPrefix netip.Prefix
+	if !data.IPPrefix.IsNull() {
+		prefix, err := parseIPOrPrefix(data.IPPrefix.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ip_prefix"),
+				"Invalid IP Prefix",
+				err.Error(),
+			)
+			return
+		}
+		ipPrefix = prefix
+	}
+
+	servers, err := d.client.GroupListServers(data.Group.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Bastion Group Servers",
+			err.Error(),
+		)
+		return
+	}
+
+	data.Servers = []groupServerModel{}
+	for _, server := range servers {
+		entry := flattenGroupServersEntry(server)
+
+		if ipPrefix.IsValid() && !prefixContainsACL(ipPrefix, server.IP) {
+			continue
+		}
+		if !data.Port.IsNull() && data.Port.ValueString() != entry.Port.ValueString() {
+			continue
+		}
+		if !data.Protocol.IsNull() {
+			protocol := "ssh"
+			if !entry.Protocol.IsNull() {
+				protocol = entry.Protocol.ValueString()
+			}
+			if data.Protocol.ValueString() != protocol {
+				continue
+			}
+		}
+
+		model := groupServerModel{
+			ImportID: types.StringValue(generateServerAccessID(&GroupServerResourceModel{
+				Group:      data.Group,
+				IP:         entry.IP,
+				Port:       entry.Port,
+				User:       entry.User,
+				Protocol:   entry.Protocol,
+				ProxyIP:    entry.ProxyIP,
+				ProxyPort:  entry.ProxyPort,
+				ProxyUser:  entry.ProxyUser,
+				RemotePort: entry.RemotePort,
+			})),
+			IP:            entry.IP,
+			Port:          entry.Port,
+			User:          entry.User,
+			Protocol:      entry.Protocol,
+			ProxyIP:       entry.ProxyIP,
+			ProxyPort:     entry.ProxyPort,
+			ProxyUser:     entry.ProxyUser,
+			RemotePort:    entry.RemotePort,
+			Comment:       entry.Comment,
+			ForceKey:      entry.ForceKey,
+			ForcePassword: entry.ForcePassword,
+			ReverseDNS:    types.StringPointerValue(server.ReverseDNS),
+			AddedBy:       types.StringValue(server.AddedBy),
+			AddedDate:     types.StringValue(server.AddedDate),
+			Expiry:        types.Int64Null(),
+		}
+		if server.Expiry != nil {
+			model.Expiry = types.Int64Value(int64(*server.Expiry))
+		}
+
+		data.Servers = append(data.Servers, model)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// parseIPOrPrefix parses a subnet, or a single IP as a host prefix, in the canonical form of The Bastion so that
+// e.g. "10.00.0.1" and "10.0.0.1/32" designate the same address.
+func parseIPOrPrefix(value string) (netip.Prefix, error) {
+	canonical, err := bastion.CanonicalIP(value)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+
+	if strings.Contains(canonical, "/") {
+		return netip.ParsePrefix(canonical)
+	}
+
+	addr, err := netip.ParseAddr(canonical)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// prefixContainsACL checks if the IP or subnet of an ACL lies entirely within the given prefix.
+func prefixContainsACL(prefix netip.Prefix, aclIP string) bool {
+	aclPrefix, err := parseIPOrPrefix(aclIP)
+	if err != nil {
+		return false
+	}
+	return aclPrefix.Bits() >= prefix.Bits() && prefix.Contains(aclPrefix.Addr())
+}
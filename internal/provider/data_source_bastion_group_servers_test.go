@@ -0,0 +1,176 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/netip"
+	"testing"
+
+	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/adfinis/terraform-provider-bastion/internal/provider/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccGroupServersDataSource(t *testing.T) {
+	err := testutils.CreateGroup("testgrpserversds", "bastionadmin", bastion.ED25519)
+	if err != nil {
+		t.Errorf("Unable to create test group: %s", err)
+	}
+	for _, access := range [][]string{
+		{"192.168.1.230", "22", "root"},
+		{"192.168.1.231", "2222", "admin"},
+		{"10.0.0.0/24", "22", "root"},
+	} {
+		err := testutils.CreateGroupServerAccess("testgrpserversds", access[0], access[1], access[2])
+		if err != nil {
+			t.Errorf("Unable to create group server access: %s", err)
+		}
+	}
+	err = testutils.CreateGroupServerAccessWithProtocol("testgrpserversds", "192.168.1.230", "22", "sftp")
+	if err != nil {
+		t.Errorf("Unable to create group server access: %s", err)
+	}
+
+	t.Cleanup(func() {
+		err := testutils.DeleteGroup("testgrpserversds")
+		if err != nil {
+			t.Errorf("Unable to delete test group: %s", err)
+		}
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// All accesses
+			{
+				Config: testAccGroupServersDataSourceConfig("testgrpserversds", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bastion_group_servers.test", "servers.#", "4"),
+					resource.TestCheckResourceAttrSet("data.bastion_group_servers.test", "servers.0.added_by"),
+					resource.TestCheckResourceAttrSet("data.bastion_group_servers.test", "servers.0.import_id"),
+				),
+			},
+			// Filter by prefix
+			{
+				Config: testAccGroupServersDataSourceConfig("testgrpserversds", `ip_prefix = "192.168.1.0/24"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bastion_group_servers.test", "servers.#", "3"),
+				),
+			},
+			// Filter by port
+			{
+				Config: testAccGroupServersDataSourceConfig("testgrpserversds", `port = "2222"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bastion_group_servers.test", "servers.#", "1"),
+					resource.TestCheckResourceAttr("data.bastion_group_servers.test", "servers.0.ip", "192.168.1.231"),
+					resource.TestCheckResourceAttr("data.bastion_group_servers.test", "servers.0.user", "admin"),
+					resource.TestCheckResourceAttr("data.bastion_group_servers.test", "servers.0.import_id", "testgrpserversds:192.168.1.231:2222:admin"),
+				),
+			},
+			// Filter by protocol
+			{
+				Config: testAccGroupServersDataSourceConfig("testgrpserversds", `protocol = "sftp"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bastion_group_servers.test", "servers.#", "1"),
+					resource.TestCheckResourceAttr("data.bastion_group_servers.test", "servers.0.protocol", "sftp"),
+					resource.TestCheckNoResourceAttr("data.bastion_group_servers.test", "servers.0.user"),
+				),
+			},
+		},
+	})
+}
+
+func TestPrefixContainsACL(t *testing.T) {
+	testCases := []struct {
+		name     string
+		prefix   string
+		aclIP    string
+		expected bool
+	}{
+		{
+			name:     "IPv4 address within prefix",
+			prefix:   "192.168.1.0/24",
+			aclIP:    "192.168.1.10",
+			expected: true,
+		},
+		{
+			name:     "IPv4 address outside prefix",
+			prefix:   "192.168.1.0/24",
+			aclIP:    "192.168.2.10",
+			expected: false,
+		},
+		{
+			name:     "IPv4 subnet within prefix",
+			prefix:   "10.0.0.0/8",
+			aclIP:    "10.1.0.0/16",
+			expected: true,
+		},
+		{
+			name:     "IPv4 subnet larger than prefix",
+			prefix:   "10.1.0.0/16",
+			aclIP:    "10.0.0.0/8",
+			expected: false,
+		},
+		{
+			name:     "single IP as prefix",
+			prefix:   "192.168.1.10",
+			aclIP:    "192.168.1.10",
+			expected: true,
+		},
+		{
+			name:     "IPv6 address within prefix",
+			prefix:   "2001:db8::/32",
+			aclIP:    "2001:db8::1",
+			expected: true,
+		},
+		{
+			name:     "leading zeros",
+			prefix:   "192.168.001.0/24",
+			aclIP:    "192.168.1.10",
+			expected: true,
+		},
+		{
+			name:     "host prefix",
+			prefix:   "192.168.1.10/32",
+			aclIP:    "192.168.1.10",
+			expected: true,
+		},
+		{
+			name:     "uncompressed IPv6 address",
+			prefix:   "2001:0db8:0000:0000:0000:0000:0000:0001",
+			aclIP:    "2001:db8::1",
+			expected: true,
+		},
+		{
+			name:     "IPv4 address in IPv6 prefix",
+			prefix:   "2001:db8::/32",
+			aclIP:    "192.168.1.10",
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			prefix, err := parseIPOrPrefix(tc.prefix)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, prefixContainsACL(prefix, tc.aclIP), "prefixContainsACL(%q, %q)", tc.prefix, tc.aclIP)
+		})
+	}
+
+	_, err := parseIPOrPrefix("not-an-ip")
+	assert.Error(t, err)
+	assert.False(t, prefixContainsACL(netip.MustParsePrefix("0.0.0.0/0"), "not-an-ip"))
+}
+
+func testAccGroupServersDataSourceConfig(group, filter string) string {
+	return providerConfig + fmt.Sprintf(`
+data "bastion_group_servers" "test" {
+  group = %[1]q
+  %[2]s
+}
+`, group, filter)
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/adfinis/terraform-provider-bastion/internal/provider/testutils"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -30,6 +31,13 @@ func TestAccGroupDataSource(t *testing.T) {
 					resource.TestCheckResourceAttrSet("data.bastion_group.test", "aclkeepers.#"),
 					// Verify owner is in the owners list
 					resource.TestCheckResourceAttr("data.bastion_group.test", "owners.0", "bastionadmin"),
+					resource.TestCheckResourceAttr("data.bastion_group.test", "guests.#", "0"),
+					resource.TestCheckResourceAttr("data.bastion_group.test", "keys.#", "1"),
+					resource.TestCheckResourceAttrSet("data.bastion_group.test", "keys.0.fingerprint"),
+					resource.TestCheckResourceAttrSet("data.bastion_group.test", "keys.0.authorized_keys_line"),
+					// Servers and guest accesses are only listed on request
+					resource.TestCheckNoResourceAttr("data.bastion_group.test", "servers"),
+					resource.TestCheckNoResourceAttr("data.bastion_group.test", "guest_accesses"),
 				),
 			},
 		},
@@ -48,3 +56,75 @@ data "bastion_group" "test" {
 }
 `, groupName, owner)
 }
+
+func TestAccGroupDataSource_Accesses(t *testing.T) {
+	err := testutils.CreateAccount("testgroupdsguest1")
+	if err != nil {
+		t.Errorf("Unable to create test account: %s", err)
+	}
+
+	t.Cleanup(func() {
+		err := testutils.DeleteAccount("testgroupdsguest1")
+		if err != nil {
+			t.Errorf("Unable to delete test account: %s", err)
+		}
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGroupDataSourceAccessesConfig("testgroup-ds2", "testgroupdsguest1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bastion_group.test", "guests.#", "1"),
+					resource.TestCheckResourceAttr("data.bastion_group.test", "guests.0", "testgroupdsguest1"),
+					resource.TestCheckResourceAttr("data.bastion_group.test", "servers.#", "1"),
+					resource.TestCheckResourceAttr("data.bastion_group.test", "servers.0.type", "group"),
+					resource.TestCheckResourceAttr("data.bastion_group.test", "servers.0.group", "testgroup-ds2"),
+					resource.TestCheckResourceAttr("data.bastion_group.test", "servers.0.ip", "192.168.1.210"),
+					resource.TestCheckResourceAttr("data.bastion_group.test", "servers.0.port", "22"),
+					resource.TestCheckResourceAttr("data.bastion_group.test", "servers.0.user", "root"),
+					resource.TestCheckResourceAttr("data.bastion_group.test", "guest_accesses.#", "1"),
+					resource.TestCheckResourceAttr("data.bastion_group.test", "guest_accesses.0.account", "testgroupdsguest1"),
+					resource.TestCheckResourceAttr("data.bastion_group.test", "guest_accesses.0.accesses.#", "1"),
+					resource.TestCheckResourceAttr("data.bastion_group.test", "guest_accesses.0.accesses.0.type", "group-guest"),
+					resource.TestCheckResourceAttr("data.bastion_group.test", "guest_accesses.0.accesses.0.ip", "192.168.1.210"),
+				),
+			},
+		},
+	})
+}
+
+func testAccGroupDataSourceAccessesConfig(groupName, guest string) string {
+	return providerConfig + fmt.Sprintf(`
+resource "bastion_group" "test" {
+  group = %[1]q
+  owner = "bastionadmin"
+}
+
+resource "bastion_group_server" "test" {
+  group = bastion_group.test.group
+  ip    = "192.168.1.210"
+  port  = "22"
+  user  = "root"
+  force = true
+}
+
+resource "bastion_group_guest_access" "test" {
+  group   = bastion_group.test.group
+  account = %[2]q
+  ip      = bastion_group_server.test.ip
+  port    = bastion_group_server.test.port
+  user    = bastion_group_server.test.user
+}
+
+data "bastion_group" "test" {
+  group                  = bastion_group.test.group
+  include_servers        = true
+  include_guest_accesses = true
+
+  depends_on = [bastion_group_guest_access.test]
+}
+`, groupName, guest)
+}
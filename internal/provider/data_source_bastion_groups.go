@@ -0,0 +1,373 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/adfinis/terraform-provider-bastion/internal/provider/customtypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &GroupsDataSource{}
+var _ datasource.DataSourceWithConfigure = &GroupsDataSource{}
+
+// NewGroupsDataSource is a helper function to simplify the provider implementation.
+func NewGroupsDataSource() datasource.DataSource {
+	return &GroupsDataSource{}
+}
+
+// GroupsDataSource is the data source implementation.
+type GroupsDataSource struct {
+	client *bastion.Client
+}
+
+// groupsDataSourceModel describes the data source data model.
+type groupsDataSourceModel struct {
+	NamePrefix  types.String      `tfsdk:"name_prefix"`
+	NameRegex   types.String      `tfsdk:"name_regex"`
+	Owner       types.String      `tfsdk:"owner"`
+	Member      types.String      `tfsdk:"member"`
+	Role        types.String      `tfsdk:"role"`
+	IncludeInfo types.Bool        `tfsdk:"include_info"`
+	Names       types.List        `tfsdk:"names"`
+	Groups      []groupsItemModel `tfsdk:"groups"`
+}
+
+// groupsItemModel describes a single group of the list.
+type groupsItemModel struct {
+	Group types.String    `tfsdk:"group"`
+	Roles types.List      `tfsdk:"roles"`
+	Info  *groupInfoModel `tfsdk:"info"`
+}
+
+// groupInfoModel describes the information of a group.
+type groupInfoModel struct {
+	Owners          types.List           `tfsdk:"owners"`
+	Members         types.List           `tfsdk:"members"`
+	Gatekeepers     types.List           `tfsdk:"gatekeepers"`
+	ACLKeepers      types.List           `tfsdk:"aclkeepers"`
+	Guests          types.List           `tfsdk:"guests"`
+	Inactive        types.List           `tfsdk:"inactive"`
+	Keys            []groupKeyModel      `tfsdk:"keys"`
+	MFARequired     types.String         `tfsdk:"mfa_required"`
+	IdleLockTimeout customtypes.Duration `tfsdk:"idle_lock_timeout"`
+	IdleKillTimeout customtypes.Duration `tfsdk:"idle_kill_timeout"`
+	GuestTtlLimit   customtypes.Duration `tfsdk:"guest_ttl_limit"`
+	TryPersonalKeys types.Bool           `tfsdk:"try_personal_keys"`
+}
+
+// Metadata returns the data source type name.
+func (d *GroupsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_groups"
+}
+
+// Schema defines the schema for the data source.
+func (d *GroupsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the Bastion groups. All filters are optional and combined with a logical AND.",
+		Attributes: map[string]schema.Attribute{
+			"name_prefix": schema.StringAttribute{
+				MarkdownDescription: "Only list the groups whose name starts with this prefix",
+				Optional:            true,
+			},
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Only list the groups whose name matches this regular expression",
+				Optional:            true,
+			},
+			"owner": schema.StringAttribute{
+				MarkdownDescription: "Only list the groups owned by this account",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"member": schema.StringAttribute{
+				MarkdownDescription: "Only list the groups this account is a member of",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"role": schema.StringAttribute{
+				MarkdownDescription: "Only list the groups in which the provider account has this role. Valid values: owner, gatekeeper, aclkeeper, member, guest.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("owner", "gatekeeper", "aclkeeper", "member", "guest"),
+				},
+			},
+			"include_info": schema.BoolAttribute{
+				MarkdownDescription: "Whether to read the information of each matching group into `info`. Defaults to false.",
+				Optional:            true,
+			},
+			"names": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The names of the matching groups, sorted alphabetically",
+				Computed:            true,
+			},
+			"groups": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching groups, sorted alphabetically",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"group": schema.StringAttribute{
+							MarkdownDescription: "The name of the group",
+							Computed:            true,
+						},
+						"roles": schema.ListAttribute{
+							ElementType:         types.StringType,
+							MarkdownDescription: "The roles of the provider account in the group",
+							Computed:            true,
+						},
+						"info": schema.SingleNestedAttribute{
+							MarkdownDescription: "The information of the group. Only set if `include_info` is true.",
+							Computed:            true,
+							Attributes:          groupInfoAttributes(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the bastion client to the data source.
+func (d *GroupsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*bastion.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *bastion.Client type for data source configuration.",
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *GroupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data groupsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	listOpts := &bastion.GroupListOptions{
+		All: true,
+	}
+	if !data.NamePrefix.IsNull() {
+		listOpts.Include = []string{data.NamePrefix.ValueString() + "*"}
+	}
+
+	groups, err := d.client.GroupList(listOpts)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to List Bastion Groups",
+			err.Error(),
+		)
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !data.NameRegex.IsNull() {
+		nameRegex, err = regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"Invalid Regular Expression",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	// the group information is only read when needed, since it costs one command per group
+	needsInfo := !data.Owner.IsNull() || !data.Member.IsNull() || data.IncludeInfo.ValueBool()
+
+	names := []string{}
+	data.Groups = []groupsItemModel{}
+	for _, item := range groups {
+		if nameRegex != nil && !nameRegex.MatchString(item.Group) {
+			continue
+		}
+		if !data.Role.IsNull() && !slices.Contains(item.Flags, data.Role.ValueString()) {
+			continue
+		}
+
+		var group *bastion.Group
+		if needsInfo {
+			group, err = d.client.GroupInfo(item.Group)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Unable to Read Bastion Group",
+					fmt.Sprintf("Could not read group %s: %s", item.Group, err.Error()),
+				)
+				return
+			}
+			if !data.Owner.IsNull() && !slices.Contains(group.Owners, data.Owner.ValueString()) {
+				continue
+			}
+			if !data.Member.IsNull() && !slices.Contains(group.Members, data.Member.ValueString()) {
+				continue
+			}
+		}
+
+		roles, diags := types.ListValueFrom(ctx, types.StringType, item.Flags)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		model := groupsItemModel{
+			Group: types.StringValue(item.Group),
+			Roles: roles,
+		}
+		if data.IncludeInfo.ValueBool() {
+			info, diags := flattenGroupInfo(ctx, item.Group, group)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			model.Info = &info
+		}
+
+		names = append(names, item.Group)
+		data.Groups = append(data.Groups, model)
+	}
+
+	namesList, diags := types.ListValueFrom(ctx, types.StringType, names)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Names = namesList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// groupInfoAttributes returns the schema attributes of the information of a group.
+func groupInfoAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"owners": schema.ListAttribute{
+			ElementType:         types.StringType,
+			MarkdownDescription: "The owners of the group",
+			Computed:            true,
+		},
+		"members": schema.ListAttribute{
+			ElementType:         types.StringType,
+			MarkdownDescription: "The members of the group",
+			Computed:            true,
+		},
+		"gatekeepers": schema.ListAttribute{
+			ElementType:         types.StringType,
+			MarkdownDescription: "The gatekeepers of the group",
+			Computed:            true,
+		},
+		"aclkeepers": schema.ListAttribute{
+			ElementType:         types.StringType,
+			MarkdownDescription: "The ACL keepers of the group",
+			Computed:            true,
+		},
+		"guests": schema.ListAttribute{
+			ElementType:         types.StringType,
+			MarkdownDescription: "The guests of the group",
+			Computed:            true,
+		},
+		"inactive": schema.ListAttribute{
+			ElementType:         types.StringType,
+			MarkdownDescription: "The members and guests of the group whose account is inactive",
+			Computed:            true,
+		},
+		"keys": schema.ListNestedAttribute{
+			MarkdownDescription: "The egress public keys of the group, oldest first",
+			Computed:            true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: groupKeyAttributes(),
+			},
+		},
+		"mfa_required": schema.StringAttribute{
+			MarkdownDescription: "The MFA policy of the group, null if not set",
+			Computed:            true,
+		},
+		"idle_lock_timeout": schema.StringAttribute{
+			CustomType:          customtypes.DurationType{},
+			MarkdownDescription: "The idle lock timeout as a duration like `1h30m`, null if not set",
+			Computed:            true,
+		},
+		"idle_kill_timeout": schema.StringAttribute{
+			CustomType:          customtypes.DurationType{},
+			MarkdownDescription: "The idle kill timeout as a duration like `1h30m`, null if not set",
+			Computed:            true,
+		},
+		"guest_ttl_limit": schema.StringAttribute{
+			CustomType:          customtypes.DurationType{},
+			MarkdownDescription: "The maximum TTL of guest accesses as a duration like `7d`, null if not set",
+			Computed:            true,
+		},
+		"try_personal_keys": schema.BoolAttribute{
+			MarkdownDescription: "Whether the personal keys of the members are tried when connecting to the group servers, null if not set",
+			Computed:            true,
+		},
+	}
+}
+
+// flattenGroupInfo converts the information of a group to its data source model.
+func flattenGroupInfo(ctx context.Context, name string, group *bastion.Group) (groupInfoModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	info := groupInfoModel{
+		MFARequired:     types.StringNull(),
+		TryPersonalKeys: types.BoolNull(),
+		Keys:            []groupKeyModel{},
+	}
+
+	for _, list := range []struct {
+		target *types.List
+		values []string
+	}{
+		{&info.Owners, group.Owners},
+		{&info.Members, group.Members},
+		{&info.Gatekeepers, group.Gatekeepers},
+		{&info.ACLKeepers, group.ACLKeepers},
+		{&info.Guests, group.Guests},
+		{&info.Inactive, group.Inactive},
+	} {
+		value, d := types.ListValueFrom(ctx, types.StringType, list.values)
+		diags.Append(d...)
+		*list.target = value
+	}
+
+	for _, key := range group.SortedKeys() {
+		model, d := flattenGroupKey(ctx, &key)
+		diags.Append(d...)
+		info.Keys = append(info.Keys, model)
+	}
+
+	if group.MFARequired != nil {
+		info.MFARequired = types.StringValue(string(*group.MFARequired))
+	}
+	if group.TryPersonalKeys != nil {
+		info.TryPersonalKeys = types.BoolValue(group.TryPersonalKeys.Bool())
+	}
+	info.IdleLockTimeout = customtypes.NewDurationPointerValue(group.IdleLockTimeout)
+	info.IdleKillTimeout = customtypes.NewDurationPointerValue(group.IdleKillTimeout)
+	info.GuestTtlLimit = customtypes.NewDurationPointerValue(group.GuestTtlLimit)
+
+	return info, diags
+}
@@ -0,0 +1,81 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/adfinis/terraform-provider-bastion/internal/provider/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccGroupsDataSource(t *testing.T) {
+	for _, group := range []string{"testgroupsds-a", "testgroupsds-b"} {
+		err := testutils.CreateGroup(group, "bastionadmin", bastion.ED25519)
+		if err != nil {
+			t.Errorf("Unable to create test group: %s", err)
+		}
+	}
+
+	t.Cleanup(func() {
+		for _, group := range []string{"testgroupsds-a", "testgroupsds-b"} {
+			err := testutils.DeleteGroup(group)
+			if err != nil {
+				t.Errorf("Unable to delete test group: %s", err)
+			}
+		}
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Filter by prefix
+			{
+				Config: providerConfig + `
+data "bastion_groups" "test" {
+  name_prefix = "testgroupsds-"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bastion_groups.test", "names.#", "2"),
+					resource.TestCheckResourceAttr("data.bastion_groups.test", "names.0", "testgroupsds-a"),
+					resource.TestCheckResourceAttr("data.bastion_groups.test", "names.1", "testgroupsds-b"),
+					resource.TestCheckTypeSetElemAttr("data.bastion_groups.test", "groups.0.roles.*", "owner"),
+					resource.TestCheckNoResourceAttr("data.bastion_groups.test", "groups.0.info"),
+				),
+			},
+			// Filter by regex, owner and role, with the group information
+			{
+				Config: providerConfig + `
+data "bastion_groups" "test" {
+  name_regex   = "^testgroupsds-b$"
+  owner        = "bastionadmin"
+  role         = "owner"
+  include_info = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bastion_groups.test", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.bastion_groups.test", "groups.0.group", "testgroupsds-b"),
+					resource.TestCheckResourceAttr("data.bastion_groups.test", "groups.0.info.owners.0", "bastionadmin"),
+					resource.TestCheckResourceAttr("data.bastion_groups.test", "groups.0.info.keys.#", "1"),
+				),
+			},
+			// Filter by a member without any group
+			{
+				Config: providerConfig + `
+data "bastion_groups" "test" {
+  name_prefix = "testgroupsds-"
+  member      = "nonexistent"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bastion_groups.test", "names.#", "0"),
+				),
+			},
+		},
+	})
+}
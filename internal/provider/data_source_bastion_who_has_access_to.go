@@ -0,0 +1,158 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"slices"
+
+	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/adfinis/terraform-provider-bastion/internal/provider/customtypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &WhoHasAccessToDataSource{}
+var _ datasource.DataSourceWithConfigure = &WhoHasAccessToDataSource{}
+
+// NewWhoHasAccessToDataSource is a helper function to simplify the provider implementation.
+func NewWhoHasAccessToDataSource() datasource.DataSource {
+	return &WhoHasAccessToDataSource{}
+}
+
+// WhoHasAccessToDataSource is the data source implementation.
+type WhoHasAccessToDataSource struct {
+	client *bastion.Client
+}
+
+// whoHasAccessToDataSourceModel describes the data source data model.
+type whoHasAccessToDataSourceModel struct {
+	IP       customtypes.IPAddress        `tfsdk:"ip"`
+	Port     types.String                 `tfsdk:"port"`
+	User     types.String                 `tfsdk:"user"`
+	Names    types.List                   `tfsdk:"names"`
+	Accounts []whoHasAccessToAccountModel `tfsdk:"accounts"`
+}
+
+// whoHasAccessToAccountModel describes a single account having access.
+type whoHasAccessToAccountModel struct {
+	Account types.String `tfsdk:"account"`
+	Type    types.String `tfsdk:"type"`
+	Group   types.String `tfsdk:"group"`
+}
+
+// Metadata returns the data source type name.
+func (d *WhoHasAccessToDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_who_has_access_to"
+}
+
+// Schema defines the schema for the data source.
+func (d *WhoHasAccessToDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the Bastion accounts having access to a server or subnet, either through a personal access or through a group.",
+		Attributes: map[string]schema.Attribute{
+			"ip": schema.StringAttribute{
+				CustomType:          customtypes.IPAddressType{},
+				MarkdownDescription: "IP or subnet of the server",
+				Required:            true,
+			},
+			"port": schema.StringAttribute{
+				MarkdownDescription: "Only list the accesses to this port",
+				Optional:            true,
+			},
+			"user": schema.StringAttribute{
+				MarkdownDescription: "Only list the accesses with this remote user",
+				Optional:            true,
+			},
+			"names": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The names of the accounts having access, sorted alphabetically and without duplicates",
+				Computed:            true,
+			},
+			"accounts": schema.ListNestedAttribute{
+				MarkdownDescription: "The accounts having access, with one entry per account and access source",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"account": schema.StringAttribute{
+							MarkdownDescription: "The name of the account",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The source of the access: personal, group or group-guest",
+							Computed:            true,
+						},
+						"group": schema.StringAttribute{
+							MarkdownDescription: "The group granting the access, null for personal accesses",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the bastion client to the data source.
+func (d *WhoHasAccessToDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*bastion.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *bastion.Client type for data source configuration.",
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *WhoHasAccessToDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data whoHasAccessToDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	accesses, err := d.client.WhoHasAccessTo(data.IP.ValueCanonical(), &bastion.WhoHasAccessToOptions{
+		Port: data.Port.ValueString(),
+		User: data.User.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Bastion Accesses",
+			err.Error(),
+		)
+		return
+	}
+
+	names := []string{}
+	data.Accounts = []whoHasAccessToAccountModel{}
+	for _, access := range accesses {
+		names = append(names, access.Account)
+		data.Accounts = append(data.Accounts, whoHasAccessToAccountModel{
+			Account: types.StringValue(access.Account),
+			Type:    types.StringValue(access.AccessType),
+			Group:   types.StringPointerValue(access.Group),
+		})
+	}
+	// accesses are sorted by account, so duplicates are adjacent
+	names = slices.Compact(names)
+
+	namesList, diags := types.ListValueFrom(ctx, types.StringType, names)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Names = namesList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,100 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/adfinis/terraform-provider-bastion/internal/provider/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccWhoHasAccessToDataSource(t *testing.T) {
+	err := testutils.CreateAccounts("testwhohas1", "testwhohas2")
+	if err != nil {
+		t.Errorf("Unable to create test accounts: %s", err)
+	}
+	err = testutils.CreateGroup("testwhohasgroup1", "bastionadmin", bastion.ED25519)
+	if err != nil {
+		t.Errorf("Unable to create test group: %s", err)
+	}
+
+	t.Cleanup(func() {
+		err := testutils.DeleteGroup("testwhohasgroup1")
+		if err != nil {
+			t.Errorf("Unable to delete test group: %s", err)
+		}
+		err = testutils.DeleteAccounts("testwhohas1", "testwhohas2")
+		if err != nil {
+			t.Errorf("Unable to delete test accounts: %s", err)
+		}
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWhoHasAccessToDataSourceConfig("testwhohas1", "testwhohas2", "testwhohasgroup1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bastion_who_has_access_to.test", "names.#", "2"),
+					resource.TestCheckResourceAttr("data.bastion_who_has_access_to.test", "names.0", "testwhohas1"),
+					resource.TestCheckResourceAttr("data.bastion_who_has_access_to.test", "names.1", "testwhohas2"),
+					resource.TestCheckResourceAttr("data.bastion_who_has_access_to.test", "accounts.0.type", "personal"),
+					resource.TestCheckNoResourceAttr("data.bastion_who_has_access_to.test", "accounts.0.group"),
+					resource.TestCheckResourceAttr("data.bastion_who_has_access_to.test", "accounts.1.type", "group"),
+					resource.TestCheckResourceAttr("data.bastion_who_has_access_to.test", "accounts.1.group", "testwhohasgroup1"),
+					resource.TestCheckResourceAttr("data.bastion_who_has_access_to.nobody", "names.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccWhoHasAccessToDataSourceConfig(personalAccount, groupAccount, group string) string {
+	return providerConfig + fmt.Sprintf(`
+resource "bastion_account_personal_access" "test" {
+  account = %[1]q
+  ip      = "192.168.40.10"
+  port    = "22"
+  user    = "root"
+}
+
+resource "bastion_group_member" "test" {
+  group   = %[3]q
+  account = %[2]q
+}
+
+resource "bastion_group_server" "test" {
+  group = %[3]q
+  ip    = "192.168.40.10"
+  port  = "22"
+  user  = "root"
+}
+
+data "bastion_who_has_access_to" "test" {
+  ip   = "192.168.40.10"
+  port = "22"
+  user = "root"
+
+  depends_on = [
+    bastion_account_personal_access.test,
+    bastion_group_member.test,
+    bastion_group_server.test,
+  ]
+}
+
+data "bastion_who_has_access_to" "nobody" {
+  ip = "192.168.40.11"
+
+  depends_on = [
+    bastion_account_personal_access.test,
+    bastion_group_member.test,
+    bastion_group_server.test,
+  ]
+}
+`, personalAccount, groupAccount, group)
+}
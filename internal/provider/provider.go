@@ -5,6 +5,7 @@ package provider
 
 import (
 	"context"
+	"net"
 	"os"
 	"strconv"
 
@@ -28,6 +29,9 @@ type BastionProvider struct {
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
 	version string
+
+	// resolver resolves the hostname of access targets.
+	resolver HostnameResolver
 }
 
 // BastionProviderModel describes the provider data model.
@@ -248,27 +252,47 @@ func (p *BastionProvider) Resources(ctx context.Context) []func() resource.Resou
 	return []func() resource.Resource{
 		NewAccountResource,
 		NewAccountCommandResource,
+		NewAccountCommandsResource,
 		NewAccountPIVPolicyResource,
+		NewAccountPersonalAccessResource,
+		NewAccountMFAResetResource,
+		NewAccountEgressPasswordResource,
 		NewGroupResource,
 		NewGroupOwnerResource,
+		NewGroupOwnersResource,
 		NewGroupGatekeeperResource,
+		NewGroupGatekeepersResource,
 		NewGroupACLKeeperResource,
+		NewGroupACLKeepersResource,
 		NewGroupMemberResource,
-		NewGroupServerResource,
-		NewGroupGuestAccessResource,
+		NewGroupMembersResource,
+		NewGroupServerResource(p.resolver),
+		NewGroupServersResource,
+		NewGroupGuestAccessResource(p.resolver),
+		NewGroupEgressPasswordResource,
+		NewGroupEgressKeyResource,
 	}
 }
 
 func (p *BastionProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewGroupDataSource,
+		NewGroupsDataSource,
+		NewGroupServersDataSource,
+		NewAccountDataSource,
+		NewAccountsDataSource,
+		NewAccountAccessesDataSource,
+		NewWhoHasAccessToDataSource,
+		NewAccountEgressKeysDataSource,
+		NewGroupKeysDataSource,
 	}
 }
 
 func New(version string) func() provider.Provider {
 	return func() provider.Provider {
 		return &BastionProvider{
-			version: version,
+			version:  version,
+			resolver: net.DefaultResolver,
 		}
 	}
 }
@@ -15,6 +15,14 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 	"bastion": providerserver.NewProtocol6WithError(New("test")()),
 }
 
+// testAccProtoV6ProviderFactoriesWithResolver returns provider factories resolving the hostname of access targets with
+// the given resolver instead of the DNS.
+func testAccProtoV6ProviderFactoriesWithResolver(resolver HostnameResolver) map[string]func() (tfprotov6.ProviderServer, error) {
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"bastion": providerserver.NewProtocol6WithError(&BastionProvider{version: "test", resolver: resolver}),
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	// Check if running in acceptance test mode
 	if os.Getenv("TF_ACC") == "" {
@@ -0,0 +1,173 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// HostnameResolver resolves the hostname of an access target to its IP addresses.
+// It is satisfied by *net.Resolver, the provider uses net.DefaultResolver.
+type HostnameResolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// StaticResolver resolves hostnames from a fixed map, e.g. in tests.
+type StaticResolver map[string][]string
+
+// LookupHost returns the addresses of the host, or a not found error if the host is not in the map.
+func (r StaticResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	addrs, ok := r[host]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	return addrs, nil
+}
+
+// resolveHostname resolves a hostname to its sorted and deduplicated IP addresses.
+func resolveHostname(ctx context.Context, resolver HostnameResolver, hostname string) ([]string, error) {
+	addrs, err := resolver.LookupHost(ctx, hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	ips := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		ip, err := netip.ParseAddr(addr)
+		if err != nil {
+			return nil, fmt.Errorf("hostname %s resolved to invalid address %q: %w", hostname, addr, err)
+		}
+		ips = append(ips, ip.Unmap().WithZone("").String())
+	}
+	if len(ips) == 0 {
+		return nil, fmt.Errorf("hostname %s did not resolve to any address", hostname)
+	}
+
+	slices.Sort(ips)
+	return slices.Compact(ips), nil
+}
+
+// resolveUnknownIPs returns the addresses the hostname resolved to, resolving it now when it was unknown at plan time.
+func resolveUnknownIPs(ctx context.Context, resolver HostnameResolver, hostname types.String, resolvedIPs types.List) (types.List, error) {
+	if !resolvedIPs.IsUnknown() {
+		return resolvedIPs, nil
+	}
+	if hostname.IsNull() {
+		return types.ListNull(types.StringType), nil
+	}
+
+	ips, err := resolveHostname(ctx, resolver, hostname.ValueString())
+	if err != nil {
+		return resolvedIPs, fmt.Errorf("could not resolve hostname %s: %w", hostname.ValueString(), err)
+	}
+
+	list, diags := types.ListValueFrom(ctx, types.StringType, ips)
+	if diags.HasError() {
+		return resolvedIPs, fmt.Errorf("could not set resolved_ips")
+	}
+	return list, nil
+}
+
+// accessTargets returns the IP addresses an access is granted to, either its ip or the resolved addresses of its hostname.
+func accessTargets(ctx context.Context, ip string, resolvedIPs types.List) ([]string, error) {
+	if !resolvedIPs.IsNull() && !resolvedIPs.IsUnknown() {
+		var ips []string
+		if diags := resolvedIPs.ElementsAs(ctx, &ips, false); diags.HasError() {
+			return nil, fmt.Errorf("could not read resolved_ips")
+		}
+		return ips, nil
+	}
+	return []string{ip}, nil
+}
+
+// diffTargets returns the addresses added to and removed from the prior ones.
+func diffTargets(prior, current []string) (added, removed []string) {
+	for _, ip := range current {
+		if !slices.Contains(prior, ip) {
+			added = append(added, ip)
+		}
+	}
+	for _, ip := range prior {
+		if !slices.Contains(current, ip) {
+			removed = append(removed, ip)
+		}
+	}
+	return added, removed
+}
+
+// planResolvedIPs resolves the configured hostname and plans its addresses, so that changes in the resolution show up as diffs.
+func planResolvedIPs(ctx context.Context, resolver HostnameResolver, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var hostname types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("hostname"), &hostname)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if hostname.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("resolved_ips"), types.ListUnknown(types.StringType))...)
+		return
+	}
+	if hostname.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("resolved_ips"), types.ListNull(types.StringType))...)
+		return
+	}
+
+	ips, err := resolveHostname(ctx, resolver, hostname.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("hostname"),
+			"Error Resolving Hostname",
+			fmt.Sprintf("Could not resolve hostname %s: %s", hostname.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	resolvedIPs, diags := types.ListValueFrom(ctx, types.StringType, ips)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("resolved_ips"), resolvedIPs)...)
+}
+
+// hostnameAttribute returns the schema of the hostname of an access target.
+func hostnameAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "Hostname of the access target, resolved at plan time, or at apply time when it is only known then. " +
+			"One access is granted per resolved address, " +
+			"changes in the resolution are applied without replacing the resource. Exactly one of `ip` and `hostname` must be set.",
+		Optional: true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+		Validators: []validator.String{
+			stringvalidator.ExactlyOneOf(path.MatchRoot("ip")),
+			stringvalidator.LengthAtLeast(1),
+		},
+	}
+}
+
+// resolvedIPsAttribute returns the schema of the addresses the hostname of an access target resolved to.
+func resolvedIPsAttribute() schema.ListAttribute {
+	return schema.ListAttribute{
+		MarkdownDescription: "Addresses `hostname` resolved to, one access is granted per address. Null when `ip` is used.",
+		ElementType:         types.StringType,
+		Computed:            true,
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveHostname(t *testing.T) {
+	resolver := StaticResolver{
+		"fortress.example.com":   {"192.168.1.161", "192.168.1.160", "192.168.1.161"},
+		"watchtower.example.com": {"2001:db8::1", "::ffff:10.0.0.1"},
+		"phantom.example.com":    {},
+		"bizarro.example.com":    {"not-an-ip"},
+	}
+
+	testCases := []struct {
+		name      string
+		hostname  string
+		expected  []string
+		expectErr bool
+	}{
+		{
+			name:     "sorted and deduplicated",
+			hostname: "fortress.example.com",
+			expected: []string{"192.168.1.160", "192.168.1.161"},
+		},
+		{
+			name:     "IPv6 and IPv4-mapped addresses",
+			hostname: "watchtower.example.com",
+			expected: []string{"10.0.0.1", "2001:db8::1"},
+		},
+		{
+			name:      "no address",
+			hostname:  "phantom.example.com",
+			expectErr: true,
+		},
+		{
+			name:      "invalid address",
+			hostname:  "bizarro.example.com",
+			expectErr: true,
+		},
+		{
+			name:      "unknown host",
+			hostname:  "kandor.example.com",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ips, err := resolveHostname(context.Background(), resolver, tc.hostname)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, ips)
+		})
+	}
+}
+
+func TestResolveUnknownIPs(t *testing.T) {
+	ctx := context.Background()
+	resolver := StaticResolver{
+		"fortress.example.com": {"192.168.1.161", "192.168.1.160"},
+	}
+	planned := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("192.168.1.160")})
+
+	// Known addresses are kept
+	resolvedIPs, err := resolveUnknownIPs(ctx, resolver, types.StringValue("fortress.example.com"), planned)
+	assert.NoError(t, err)
+	assert.Equal(t, planned, resolvedIPs)
+
+	// Hostnames unknown at plan time are resolved at apply time
+	resolvedIPs, err = resolveUnknownIPs(ctx, resolver, types.StringValue("fortress.example.com"), types.ListUnknown(types.StringType))
+	assert.NoError(t, err)
+	assert.Equal(t, types.ListValueMust(types.StringType, []attr.Value{
+		types.StringValue("192.168.1.160"),
+		types.StringValue("192.168.1.161"),
+	}), resolvedIPs)
+
+	// Accesses to an ip have no resolved addresses
+	resolvedIPs, err = resolveUnknownIPs(ctx, resolver, types.StringNull(), types.ListUnknown(types.StringType))
+	assert.NoError(t, err)
+	assert.True(t, resolvedIPs.IsNull())
+
+	_, err = resolveUnknownIPs(ctx, resolver, types.StringValue("kandor.example.com"), types.ListUnknown(types.StringType))
+	assert.Error(t, err)
+}
+
+func TestDiffTargets(t *testing.T) {
+	added, removed := diffTargets(
+		[]string{"192.168.1.160", "192.168.1.161"},
+		[]string{"192.168.1.161", "192.168.1.162"},
+	)
+	assert.Equal(t, []string{"192.168.1.162"}, added)
+	assert.Equal(t, []string{"192.168.1.160"}, removed)
+
+	added, removed = diffTargets([]string{"192.168.1.160"}, []string{"192.168.1.160"})
+	assert.Empty(t, added)
+	assert.Empty(t, removed)
+}
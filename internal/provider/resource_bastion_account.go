@@ -9,7 +9,9 @@ import (
 	"strconv"
 
 	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/adfinis/terraform-provider-bastion/internal/provider/customtypes"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -26,6 +28,8 @@ import (
 var _ resource.Resource = &AccountResource{}
 var _ resource.ResourceWithImportState = &AccountResource{}
 var _ resource.ResourceWithConfigure = &AccountResource{}
+var _ resource.ResourceWithValidateConfig = &AccountResource{}
+var _ resource.ResourceWithModifyPlan = &AccountResource{}
 
 // NewAccountResource is a helper function to simplify the provider implementation.
 func NewAccountResource() resource.Resource {
@@ -39,25 +43,34 @@ type AccountResource struct {
 
 // AccountResourceModel describes the resource data model.
 type AccountResourceModel struct {
-	Account                     types.String `tfsdk:"account"`
-	UID                         types.Int64  `tfsdk:"uid"`
-	UIDAuto                     types.Bool   `tfsdk:"uid_auto"`
-	PublicKey                   types.String `tfsdk:"public_key"`
-	NoKey                       types.Bool   `tfsdk:"no_key"`
-	ImmutableKey                types.Bool   `tfsdk:"immutable_key"`
-	Comment                     types.String `tfsdk:"comment"`
-	TTL                         types.Int64  `tfsdk:"ttl"`
-	AlwaysActive                types.Bool   `tfsdk:"always_active"`
-	OshOnly                     types.Bool   `tfsdk:"osh_only"`
-	MaxInactiveDays             types.Int64  `tfsdk:"max_inactive_days"`
-	PamAuthBypass               types.Bool   `tfsdk:"pam_auth_bypass"`
-	MFAPasswordRequired         types.String `tfsdk:"mfa_password_required"`
-	MFATOTPRequired             types.String `tfsdk:"mfa_totp_required"`
-	EgressStrictHostKeyChecking types.String `tfsdk:"egress_strict_host_key_checking"`
-	EgressSessionMultiplexing   types.String `tfsdk:"egress_session_multiplexing"`
-	PersonalEgressMFARequired   types.String `tfsdk:"personal_egress_mfa_required"`
-	IdleIgnore                  types.Bool   `tfsdk:"idle_ignore"`
-	PubkeyAuthOptional          types.Bool   `tfsdk:"pubkey_auth_optional"`
+	Account                     types.String         `tfsdk:"account"`
+	UID                         types.Int64          `tfsdk:"uid"`
+	UIDAuto                     types.Bool           `tfsdk:"uid_auto"`
+	PublicKey                   types.String         `tfsdk:"public_key"`
+	NoKey                       types.Bool           `tfsdk:"no_key"`
+	ImmutableKey                types.Bool           `tfsdk:"immutable_key"`
+	Comment                     types.String         `tfsdk:"comment"`
+	TTL                         customtypes.Duration `tfsdk:"ttl"`
+	ExpiresAt                   types.String         `tfsdk:"expires_at"`
+	AlwaysActive                types.Bool           `tfsdk:"always_active"`
+	OshOnly                     types.Bool           `tfsdk:"osh_only"`
+	MaxInactiveDays             types.Int64          `tfsdk:"max_inactive_days"`
+	PamAuthBypass               types.Bool           `tfsdk:"pam_auth_bypass"`
+	MFAPasswordRequired         types.String         `tfsdk:"mfa_password_required"`
+	MFATOTPRequired             types.String         `tfsdk:"mfa_totp_required"`
+	EgressStrictHostKeyChecking types.String         `tfsdk:"egress_strict_host_key_checking"`
+	EgressSessionMultiplexing   types.String         `tfsdk:"egress_session_multiplexing"`
+	PersonalEgressMFARequired   types.String         `tfsdk:"personal_egress_mfa_required"`
+	IdleIgnore                  types.Bool           `tfsdk:"idle_ignore"`
+	PubkeyAuthOptional          types.Bool           `tfsdk:"pubkey_auth_optional"`
+	Frozen                      types.Bool           `tfsdk:"frozen"`
+	FreezeReason                types.String         `tfsdk:"freeze_reason"`
+	AutoUnexpire                types.Bool           `tfsdk:"auto_unexpire"`
+	IsExpired                   types.Bool           `tfsdk:"is_expired"`
+	IsActive                    types.Bool           `tfsdk:"is_active"`
+	LastActivity                types.Int64          `tfsdk:"last_activity"`
+	MFATOTPConfigured           types.Bool           `tfsdk:"mfa_totp_configured"`
+	MFAPasswordConfigured       types.Bool           `tfsdk:"mfa_password_configured"`
 }
 
 func (r *AccountResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -77,12 +90,13 @@ func (r *AccountResource) Schema(ctx context.Context, req resource.SchemaRequest
 				},
 			},
 			"uid": schema.Int64Attribute{
-				MarkdownDescription: "The UID of the Bastion account. Mutually exclusive with uid_auto.",
+				MarkdownDescription: "The UID of the Bastion account. Mutually exclusive with uid_auto. Computed when uid_auto is used.",
 				Optional:            true,
+				Computed:            true,
 				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
 					int64planmodifier.RequiresReplace(),
 				},
-				WriteOnly: true,
 			},
 			"uid_auto": schema.BoolAttribute{
 				MarkdownDescription: "Whether to automatically assign a UID. Mutually exclusive with uid.",
@@ -116,13 +130,17 @@ func (r *AccountResource) Schema(ctx context.Context, req resource.SchemaRequest
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
-			"ttl": schema.Int64Attribute{
-				MarkdownDescription: "Time to live for the account in seconds.",
+			"ttl": schema.StringAttribute{
+				CustomType:          customtypes.DurationType{},
+				MarkdownDescription: "Time to live for the account, as a number of seconds or a duration like `90m` or `7d`.",
 				Optional:            true,
-				PlanModifiers: []planmodifier.Int64{
-					int64planmodifier.RequiresReplace(),
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					customtypes.DurationPlanModifier(),
+					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"expires_at": expiresAtAttribute("account"),
 			"always_active": schema.BoolAttribute{
 				MarkdownDescription: "Whether the account is always active.",
 				Optional:            true,
@@ -136,8 +154,12 @@ func (r *AccountResource) Schema(ctx context.Context, req resource.SchemaRequest
 				Default:             booldefault.StaticBool(false),
 			},
 			"max_inactive_days": schema.Int64Attribute{
-				MarkdownDescription: "Maximum number of days of inactivity before the account is considered inactive.",
+				MarkdownDescription: "Maximum number of days of inactivity before the account is considered inactive. Computed from the account when not set.",
 				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
 			},
 			"pam_auth_bypass": schema.BoolAttribute{
 				MarkdownDescription: "Whether PAM authentication is bypassed for this account.",
@@ -166,6 +188,8 @@ func (r *AccountResource) Schema(ctx context.Context, req resource.SchemaRequest
 			"egress_strict_host_key_checking": schema.StringAttribute{
 				MarkdownDescription: "Egress strict host key checking policy. Valid values: yes, accept-new, no, ask, default, bypass.",
 				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("default"),
 				Validators: []validator.String{
 					stringvalidator.OneOf("yes", "accept-new", "no", "ask", "default", "bypass"),
 				},
@@ -173,6 +197,8 @@ func (r *AccountResource) Schema(ctx context.Context, req resource.SchemaRequest
 			"egress_session_multiplexing": schema.StringAttribute{
 				MarkdownDescription: "Egress session multiplexing policy. Valid values: yes, no, default.",
 				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("default"),
 				Validators: []validator.String{
 					stringvalidator.OneOf("yes", "no", "default"),
 				},
@@ -195,6 +221,53 @@ func (r *AccountResource) Schema(ctx context.Context, req resource.SchemaRequest
 			"pubkey_auth_optional": schema.BoolAttribute{
 				MarkdownDescription: "Whether public key authentication is optional for this account.",
 				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"frozen": schema.BoolAttribute{
+				MarkdownDescription: "Whether the account is frozen. A frozen account cannot connect to The Bastion.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"freeze_reason": schema.StringAttribute{
+				MarkdownDescription: "The reason the account is frozen. Can only be set when frozen is true.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"auto_unexpire": schema.BoolAttribute{
+				MarkdownDescription: "Whether to reactivate the account on apply when it expired because of inactivity.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"is_expired": schema.BoolAttribute{
+				MarkdownDescription: "Whether the account is expired because of inactivity.",
+				Computed:            true,
+			},
+			"is_active": schema.BoolAttribute{
+				MarkdownDescription: "Whether the account is active.",
+				Computed:            true,
+			},
+			"last_activity": schema.Int64Attribute{
+				MarkdownDescription: "Unix timestamp of the last activity of the account, null if the account never connected.",
+				Computed:            true,
+			},
+			"mfa_totp_configured": schema.BoolAttribute{
+				MarkdownDescription: "Whether the account has TOTP configured. Use `bastion_account_mfa_reset` to reset it.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"mfa_password_configured": schema.BoolAttribute{
+				MarkdownDescription: "Whether the account has an MFA password configured. Use `bastion_account_mfa_reset` to reset it.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 		},
 	}
@@ -218,6 +291,55 @@ func (r *AccountResource) Configure(ctx context.Context, req resource.ConfigureR
 	r.client = client
 }
 
+// ValidateConfig validates the resource configuration.
+func (r *AccountResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config AccountResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.FreezeReason.IsNull() && !config.Frozen.IsUnknown() && !config.Frozen.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("freeze_reason"),
+			"Invalid Configuration",
+			"'freeze_reason' can only be set when 'frozen' is true.",
+		)
+	}
+}
+
+// ModifyPlan validates the expiry of the account and plans the reactivation of expired accounts when auto_unexpire is set.
+func (r *AccountResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkExpiresAtPlan(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Nothing to do on create or destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state AccountResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.AutoUnexpire.ValueBool() || !state.IsExpired.ValueBool() {
+		return
+	}
+
+	plan.IsExpired = types.BoolValue(false)
+	plan.IsActive = types.BoolUnknown()
+	plan.LastActivity = types.Int64Unknown()
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *AccountResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan AccountResourceModel
@@ -293,7 +415,8 @@ func (r *AccountResource) Create(ctx context.Context, req resource.CreateRequest
 		createOpts.ImmutableKey = plan.ImmutableKey.ValueBool()
 	}
 
-	if !plan.MaxInactiveDays.IsNull() {
+	// max_inactive_days is unknown when not configured, the account then keeps the bastion default
+	if !plan.MaxInactiveDays.IsUnknown() && !plan.MaxInactiveDays.IsNull() {
 		createOpts.MaxInactiveDays = uint(plan.MaxInactiveDays.ValueInt64())
 	}
 
@@ -302,7 +425,20 @@ func (r *AccountResource) Create(ctx context.Context, req resource.CreateRequest
 	}
 
 	if !plan.TTL.IsNull() {
-		createOpts.TTL = int(plan.TTL.ValueInt64())
+		createOpts.TTL = plan.TTL.ValueDuration()
+	}
+
+	if !plan.ExpiresAt.IsNull() && !plan.ExpiresAt.IsUnknown() {
+		ttl, err := ttlFromExpiresAt(plan.ExpiresAt)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("expires_at"),
+				"Error Creating Account",
+				err.Error(),
+			)
+			return
+		}
+		createOpts.TTL = ttl
 	}
 
 	if err := r.client.CreateAccount(plan.Account.ValueString(), uidO, createOpts); err != nil {
@@ -365,7 +501,7 @@ func (r *AccountResource) Create(ctx context.Context, req resource.CreateRequest
 		needsModify = true
 	}
 
-	if !plan.MaxInactiveDays.IsNull() {
+	if !plan.MaxInactiveDays.IsUnknown() && !plan.MaxInactiveDays.IsNull() {
 		val := int(plan.MaxInactiveDays.ValueInt64())
 		modifyOpts.MaxInactiveDays = &val
 		needsModify = true
@@ -390,6 +526,25 @@ func (r *AccountResource) Create(ctx context.Context, req resource.CreateRequest
 		}
 	}
 
+	if plan.Frozen.ValueBool() {
+		if err := r.client.AccountFreeze(plan.Account.ValueString(), plan.FreezeReason.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Freezing Account After Creation",
+				fmt.Sprintf("Could not freeze account %s: %s", plan.Account.ValueString(), err.Error()),
+			)
+
+			// delete the account to avoid orphaned resources
+			delErr := r.client.DeleteAccount(plan.Account.ValueString())
+			if delErr != nil {
+				resp.Diagnostics.AddError(
+					"Error Cleaning Up After Failed Account Freeze",
+					fmt.Sprintf("Could not delete account %s after failed freeze: %s", plan.Account.ValueString(), delErr.Error()),
+				)
+			}
+			return
+		}
+	}
+
 	account, err := r.client.AccountInfo(plan.Account.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -399,40 +554,9 @@ func (r *AccountResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	plan.Account = types.StringValue(account.Account)
-	plan.AlwaysActive = types.BoolValue(account.AlwaysActive.Bool())
-	plan.OshOnly = types.BoolValue(account.OshOnly.Bool())
-	plan.PamAuthBypass = types.BoolValue(account.PamAuthBypass.Bool())
-	plan.IdleIgnore = types.BoolValue(account.IdleIgnore.Bool())
-	plan.PersonalEgressMFARequired = types.StringValue(string(account.PersonalEgressMFARequired))
-
-	// Map MFA settings
-	if account.MFAPasswordBypass.Bool() {
-		plan.MFAPasswordRequired = types.StringValue("bypass")
-	} else if account.MFAPasswordRequired.Bool() {
-		plan.MFAPasswordRequired = types.StringValue("yes")
-	} else {
-		plan.MFAPasswordRequired = types.StringValue("no")
-	}
-
-	if account.MFATOTPBypass.Bool() {
-		plan.MFATOTPRequired = types.StringValue("bypass")
-	} else if account.MFATOTPRequired.Bool() {
-		plan.MFATOTPRequired = types.StringValue("yes")
-	} else {
-		plan.MFATOTPRequired = types.StringValue("no")
-	}
-
-	if account.MaxInactiveDays != "" {
-		maxInactiveDays, err := strconv.Atoi(account.MaxInactiveDays)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error Converting Max Inactive Days",
-				fmt.Sprintf("Could not convert max_inactive_days '%s' to integer: %s", account.MaxInactiveDays, err.Error()),
-			)
-			return
-		}
-		plan.MaxInactiveDays = types.Int64Value(int64(maxInactiveDays))
+	resp.Diagnostics.Append(refreshAccountModel(&plan, account)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -456,44 +580,14 @@ func (r *AccountResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	// Map response body to model
-	state.Account = types.StringValue(account.Account)
-	state.AlwaysActive = types.BoolValue(account.AlwaysActive.Bool())
-	state.OshOnly = types.BoolValue(account.OshOnly.Bool())
-	state.PamAuthBypass = types.BoolValue(account.PamAuthBypass.Bool())
-	state.IdleIgnore = types.BoolValue(account.IdleIgnore.Bool())
-
-	// Map MFA settings
-	if account.MFAPasswordBypass.Bool() {
-		state.MFAPasswordRequired = types.StringValue("bypass")
-	} else if account.MFAPasswordRequired.Bool() {
-		state.MFAPasswordRequired = types.StringValue("yes")
-	} else {
-		state.MFAPasswordRequired = types.StringValue("no")
-	}
-
-	if account.MFATOTPBypass.Bool() {
-		state.MFATOTPRequired = types.StringValue("bypass")
-	} else if account.MFATOTPRequired.Bool() {
-		state.MFATOTPRequired = types.StringValue("yes")
-	} else {
-		state.MFATOTPRequired = types.StringValue("no")
-	}
-
-	if account.PersonalEgressMFARequired != "" {
-		state.PersonalEgressMFARequired = types.StringValue(string(account.PersonalEgressMFARequired))
+	resp.Diagnostics.Append(refreshAccountModel(&state, account)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if account.MaxInactiveDays != "" {
-		maxInactiveDays, err := strconv.Atoi(account.MaxInactiveDays)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error Converting Max Inactive Days",
-				fmt.Sprintf("Could not convert max_inactive_days '%s' to integer: %s", account.MaxInactiveDays, err.Error()),
-			)
-			return
-		}
-		state.MaxInactiveDays = types.Int64Value(int64(maxInactiveDays))
+	// auto_unexpire is not stored on The Bastion, default it on import
+	if state.AutoUnexpire.IsNull() {
+		state.AutoUnexpire = types.BoolValue(false)
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -538,7 +632,8 @@ func (r *AccountResource) Update(ctx context.Context, req resource.UpdateRequest
 			modifyOpts.OshOnly = &val
 		}
 
-		if !plan.MaxInactiveDays.IsNull() {
+		// Only send max_inactive_days when it changed, it is computed from the account when not configured
+		if !plan.MaxInactiveDays.Equal(state.MaxInactiveDays) && !plan.MaxInactiveDays.IsUnknown() && !plan.MaxInactiveDays.IsNull() {
 			val := int(plan.MaxInactiveDays.ValueInt64())
 			modifyOpts.MaxInactiveDays = &val
 		}
@@ -593,6 +688,40 @@ func (r *AccountResource) Update(ctx context.Context, req resource.UpdateRequest
 		}
 	}
 
+	// Changing the reason of a frozen account requires unfreezing it first
+	if !plan.Frozen.Equal(state.Frozen) ||
+		(plan.Frozen.ValueBool() && !plan.FreezeReason.Equal(state.FreezeReason)) {
+		if state.Frozen.ValueBool() {
+			if err := r.client.AccountUnfreeze(plan.Account.ValueString()); err != nil {
+				resp.Diagnostics.AddError(
+					"Error Unfreezing Account",
+					fmt.Sprintf("Could not unfreeze account %s: %s", plan.Account.ValueString(), err.Error()),
+				)
+				return
+			}
+		}
+
+		if plan.Frozen.ValueBool() {
+			if err := r.client.AccountFreeze(plan.Account.ValueString(), plan.FreezeReason.ValueString()); err != nil {
+				resp.Diagnostics.AddError(
+					"Error Freezing Account",
+					fmt.Sprintf("Could not freeze account %s: %s", plan.Account.ValueString(), err.Error()),
+				)
+				return
+			}
+		}
+	}
+
+	if plan.AutoUnexpire.ValueBool() && state.IsExpired.ValueBool() {
+		if err := r.client.AccountUnexpire(plan.Account.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Unexpiring Account",
+				fmt.Sprintf("Could not unexpire account %s: %s", plan.Account.ValueString(), err.Error()),
+			)
+			return
+		}
+	}
+
 	// Read back the account to ensure state is consistent
 	account, err := r.client.AccountInfo(plan.Account.ValueString())
 	if err != nil {
@@ -603,31 +732,9 @@ func (r *AccountResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
-	plan.Account = types.StringValue(account.Account)
-	plan.AlwaysActive = types.BoolValue(account.AlwaysActive.Bool())
-	plan.OshOnly = types.BoolValue(account.OshOnly.Bool())
-	plan.PamAuthBypass = types.BoolValue(account.PamAuthBypass.Bool())
-	plan.IdleIgnore = types.BoolValue(account.IdleIgnore.Bool())
-
-	// Map MFA settings
-	if account.MFAPasswordBypass.Bool() {
-		plan.MFAPasswordRequired = types.StringValue("bypass")
-	} else if account.MFAPasswordRequired.Bool() {
-		plan.MFAPasswordRequired = types.StringValue("yes")
-	} else {
-		plan.MFAPasswordRequired = types.StringValue("no")
-	}
-
-	if account.MFATOTPBypass.Bool() {
-		plan.MFATOTPRequired = types.StringValue("bypass")
-	} else if account.MFATOTPRequired.Bool() {
-		plan.MFATOTPRequired = types.StringValue("yes")
-	} else {
-		plan.MFATOTPRequired = types.StringValue("no")
-	}
-
-	if account.PersonalEgressMFARequired != "" {
-		plan.PersonalEgressMFARequired = types.StringValue(string(account.PersonalEgressMFARequired))
+	resp.Diagnostics.Append(refreshAccountModel(&plan, account)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -656,3 +763,88 @@ func (r *AccountResource) Delete(ctx context.Context, req resource.DeleteRequest
 func (r *AccountResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("account"), req, resp)
 }
+
+// refreshAccountModel maps the account information returned by The Bastion to the resource model.
+func refreshAccountModel(model *AccountResourceModel, account *bastion.Account) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.Account = types.StringValue(account.Account)
+	model.UID = types.Int64Value(int64(account.UID))
+	model.AlwaysActive = types.BoolValue(account.AlwaysActive.Bool())
+	model.OshOnly = types.BoolValue(account.OshOnly.Bool())
+	model.PamAuthBypass = types.BoolValue(account.PamAuthBypass.Bool())
+	model.IdleIgnore = types.BoolValue(account.IdleIgnore.Bool())
+	model.PubkeyAuthOptional = types.BoolValue(account.PubkeyAuthOptional.Bool())
+	model.Frozen = types.BoolValue(account.IsFrozen.Bool())
+	model.IsExpired = types.BoolValue(account.IsExpired.Bool())
+	model.IsActive = types.BoolValue(account.IsActive.Bool())
+
+	var expiry *int
+	if account.IsTTLSet.Bool() {
+		expiry = &account.TTTLTimestamp
+	}
+	model.ExpiresAt = refreshExpiresAt(model.ExpiresAt, expiry)
+
+	model.LastActivity = types.Int64Null()
+	if account.LastActivity != nil && account.LastActivity.Timestamp != 0 {
+		model.LastActivity = types.Int64Value(int64(account.LastActivity.Timestamp))
+	}
+
+	model.FreezeReason = types.StringNull()
+	if account.IsFrozen.Bool() && account.FreezeInfo != nil &&
+		account.FreezeInfo.Reason != nil && *account.FreezeInfo.Reason != "" {
+		model.FreezeReason = types.StringValue(*account.FreezeInfo.Reason)
+	}
+
+	model.MFATOTPConfigured = types.BoolValue(account.MFATOTPConfigured.Bool())
+	model.MFAPasswordConfigured = types.BoolValue(account.MFAPasswordConfigured.Bool())
+
+	// Map MFA settings
+	if account.MFAPasswordBypass.Bool() {
+		model.MFAPasswordRequired = types.StringValue("bypass")
+	} else if account.MFAPasswordRequired.Bool() {
+		model.MFAPasswordRequired = types.StringValue("yes")
+	} else {
+		model.MFAPasswordRequired = types.StringValue("no")
+	}
+
+	if account.MFATOTPBypass.Bool() {
+		model.MFATOTPRequired = types.StringValue("bypass")
+	} else if account.MFATOTPRequired.Bool() {
+		model.MFATOTPRequired = types.StringValue("yes")
+	} else {
+		model.MFATOTPRequired = types.StringValue("no")
+	}
+
+	if account.PersonalEgressMFARequired != "" {
+		model.PersonalEgressMFARequired = types.StringValue(string(account.PersonalEgressMFARequired))
+	} else {
+		model.PersonalEgressMFARequired = types.StringValue(string(bastion.MFARequiredNone))
+	}
+
+	// API returns null for egress policies when the global default applies
+	model.EgressStrictHostKeyChecking = types.StringValue(string(bastion.EgressStrictHostKeyCheckingDefault))
+	if account.EgressStrictHostKeyChecking != nil {
+		model.EgressStrictHostKeyChecking = types.StringValue(string(*account.EgressStrictHostKeyChecking))
+	}
+
+	model.EgressSessionMultiplexing = types.StringValue(string(bastion.YesNoDefaultDefault))
+	if account.EgressSessionMultiplexing != nil {
+		model.EgressSessionMultiplexing = types.StringValue(string(*account.EgressSessionMultiplexing))
+	}
+
+	model.MaxInactiveDays = types.Int64Null()
+	if account.MaxInactiveDays != "" {
+		maxInactiveDays, err := strconv.Atoi(account.MaxInactiveDays)
+		if err != nil {
+			diags.AddError(
+				"Error Converting Max Inactive Days",
+				fmt.Sprintf("Could not convert max_inactive_days '%s' to integer: %s", account.MaxInactiveDays, err.Error()),
+			)
+			return diags
+		}
+		model.MaxInactiveDays = types.Int64Value(int64(maxInactiveDays))
+	}
+
+	return diags
+}
@@ -0,0 +1,298 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = &AccountCommandsResource{}
+var _ resource.ResourceWithImportState = &AccountCommandsResource{}
+var _ resource.ResourceWithConfigure = &AccountCommandsResource{}
+
+// NewAccountCommandsResource is a helper function to simplify the provider implementation.
+func NewAccountCommandsResource() resource.Resource {
+	return &AccountCommandsResource{}
+}
+
+// AccountCommandsResource is the resource implementation.
+type AccountCommandsResource struct {
+	client *bastion.Client
+}
+
+// AccountCommandsResourceModel describes the resource data model.
+type AccountCommandsResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	Account           types.String `tfsdk:"account"`
+	Commands          types.Set    `tfsdk:"commands"`
+	ProtectedCommands types.Set    `tfsdk:"protected_commands"`
+}
+
+// Metadata returns the resource type name.
+func (r *AccountCommandsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_account_commands"
+}
+
+// Schema defines the schema for the resource.
+func (r *AccountCommandsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the full set of commands granted to a Bastion account. " +
+			"Commands granted outside of this resource are revoked on the next apply, unless listed in `protected_commands`. " +
+			"Do not use together with `bastion_account_command` on the same account.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The resource identifier (account)",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"account": schema.StringAttribute{
+				MarkdownDescription: "The name of the Bastion account",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"commands": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The commands granted to the account. The special `auditor` command is supported.",
+				Required:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"protected_commands": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Commands which are never revoked by this resource, even if they are not listed in `commands`",
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the bastion client to the resource.
+func (r *AccountCommandsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*bastion.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *bastion.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *AccountCommandsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan AccountCommandsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.syncCommands(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = plan.Account
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *AccountCommandsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state AccountCommandsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	account, err := r.client.AccountInfo(state.Account.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Account Information",
+			fmt.Sprintf("Could not read account %s: %s", state.Account.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	var known, protected []string
+	if !state.Commands.IsNull() {
+		resp.Diagnostics.Append(state.Commands.ElementsAs(ctx, &known, false)...)
+	}
+	if !state.ProtectedCommands.IsNull() {
+		resp.Diagnostics.Append(state.ProtectedCommands.ElementsAs(ctx, &protected, false)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// protected commands are only tracked if they are also managed
+	commands := []string{}
+	for _, command := range grantedCommands(account) {
+		if slices.Contains(protected, command) && !slices.Contains(known, command) {
+			continue
+		}
+		commands = append(commands, command)
+	}
+
+	commandsSet, diags := types.SetValueFrom(ctx, types.StringType, commands)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Commands = commandsSet
+	state.ID = state.Account
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *AccountCommandsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan AccountCommandsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.syncCommands(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = plan.Account
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *AccountCommandsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state AccountCommandsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var commands, protected []string
+	resp.Diagnostics.Append(state.Commands.ElementsAs(ctx, &commands, false)...)
+	if !state.ProtectedCommands.IsNull() {
+		resp.Diagnostics.Append(state.ProtectedCommands.ElementsAs(ctx, &protected, false)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, command := range commands {
+		if slices.Contains(protected, command) {
+			continue
+		}
+		err := r.client.AccountRevokeCommand(state.Account.ValueString(), command)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Revoking Account Command",
+				fmt.Sprintf("Could not revoke command %s from account %s: %s", command, state.Account.ValueString(), err.Error()),
+			)
+			return
+		}
+	}
+}
+
+// ImportState imports the resource state.
+func (r *AccountCommandsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("account"), req, resp)
+}
+
+// syncCommands grants the planned commands missing on the account and revokes
+// the granted commands which are neither planned nor protected.
+func (r *AccountCommandsResource) syncCommands(ctx context.Context, plan *AccountCommandsResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var commands, protected []string
+	diags.Append(plan.Commands.ElementsAs(ctx, &commands, false)...)
+	if !plan.ProtectedCommands.IsNull() {
+		diags.Append(plan.ProtectedCommands.ElementsAs(ctx, &protected, false)...)
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	account, err := r.client.AccountInfo(plan.Account.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Error Reading Account Information",
+			fmt.Sprintf("Could not read account %s: %s", plan.Account.ValueString(), err.Error()),
+		)
+		return diags
+	}
+	granted := grantedCommands(account)
+
+	for _, command := range commands {
+		if slices.Contains(granted, command) {
+			continue
+		}
+		err := r.client.AccountGrantCommand(plan.Account.ValueString(), command)
+		if err != nil {
+			diags.AddError(
+				"Error Granting Account Command",
+				fmt.Sprintf("Could not grant command %s to account %s: %s", command, plan.Account.ValueString(), err.Error()),
+			)
+			return diags
+		}
+	}
+
+	for _, command := range granted {
+		if slices.Contains(commands, command) || slices.Contains(protected, command) {
+			continue
+		}
+		err := r.client.AccountRevokeCommand(plan.Account.ValueString(), command)
+		if err != nil {
+			diags.AddError(
+				"Error Revoking Account Command",
+				fmt.Sprintf("Could not revoke command %s from account %s: %s", command, plan.Account.ValueString(), err.Error()),
+			)
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// grantedCommands returns the commands granted to an account, including the special auditor command.
+func grantedCommands(account *bastion.Account) []string {
+	commands := slices.Clone(account.AllowedCommands)
+	if account.IsAuditor.Bool() {
+		commands = append(commands, "auditor")
+	}
+	slices.Sort(commands)
+	return commands
+}
@@ -0,0 +1,145 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/adfinis/terraform-provider-bastion/internal/provider/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccAccountCommandsResource(t *testing.T) {
+	err := testutils.CreateAccount("testcmdsuser1")
+	if err != nil {
+		t.Errorf("Unable to create test account: %s", err)
+	}
+
+	t.Cleanup(func() {
+		err := testutils.DeleteAccount("testcmdsuser1")
+		if err != nil {
+			t.Errorf("Unable to delete test account: %s", err)
+		}
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAccountCommandsResourceConfig("testcmdsuser1", `["selfAddPersonalAccess", "auditor"]`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"bastion_account_commands.test",
+						tfjsonpath.New("id"),
+						knownvalue.StringExact("testcmdsuser1"),
+					),
+					statecheck.ExpectKnownValue(
+						"bastion_account_commands.test",
+						tfjsonpath.New("commands"),
+						knownvalue.SetExact([]knownvalue.Check{
+							knownvalue.StringExact("selfAddPersonalAccess"),
+							knownvalue.StringExact("auditor"),
+						}),
+					),
+				},
+			},
+			// ImportState testing
+			{
+				ResourceName:      "bastion_account_commands.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     "testcmdsuser1",
+			},
+			// Command granted out of band is revoked
+			{
+				PreConfig: func() {
+					err := testutils.GrantAccountCommand("testcmdsuser1", "selfDelPersonalAccess")
+					if err != nil {
+						t.Errorf("Unable to grant command: %s", err)
+					}
+				},
+				Config: testAccAccountCommandsResourceConfig("testcmdsuser1", `["selfAddPersonalAccess"]`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"bastion_account_commands.test",
+						tfjsonpath.New("commands"),
+						knownvalue.SetExact([]knownvalue.Check{
+							knownvalue.StringExact("selfAddPersonalAccess"),
+						}),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccAccountCommandsResource_Protected(t *testing.T) {
+	err := testutils.CreateAccount("testcmdsuser2")
+	if err != nil {
+		t.Errorf("Unable to create test account: %s", err)
+	}
+	err = testutils.GrantAccountCommand("testcmdsuser2", "selfDelPersonalAccess")
+	if err != nil {
+		t.Errorf("Unable to grant command: %s", err)
+	}
+
+	t.Cleanup(func() {
+		err := testutils.DeleteAccount("testcmdsuser2")
+		if err != nil {
+			t.Errorf("Unable to delete test account: %s", err)
+		}
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccountCommandsResourceConfigProtected("testcmdsuser2"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"bastion_account_commands.test",
+						tfjsonpath.New("commands"),
+						knownvalue.SetExact([]knownvalue.Check{
+							knownvalue.StringExact("selfAddPersonalAccess"),
+						}),
+					),
+				},
+			},
+		},
+	})
+}
+
+// testAccAccountCommandsResourceConfig generates the Terraform configuration for testing.
+func testAccAccountCommandsResourceConfig(accountName, commands string) string {
+	config := providerConfig
+	config += fmt.Sprintf(`
+resource "bastion_account_commands" "test" {
+  account  = %[1]q
+  commands = %[2]s
+}
+`, accountName, commands)
+
+	return config
+}
+
+// testAccAccountCommandsResourceConfigProtected generates config with a protected command.
+func testAccAccountCommandsResourceConfigProtected(accountName string) string {
+	config := providerConfig
+	config += fmt.Sprintf(`
+resource "bastion_account_commands" "test" {
+  account            = %[1]q
+  commands           = ["selfAddPersonalAccess"]
+  protected_commands = ["selfDelPersonalAccess"]
+}
+`, accountName)
+
+	return config
+}
@@ -0,0 +1,233 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = &AccountMFAResetResource{}
+var _ resource.ResourceWithConfigure = &AccountMFAResetResource{}
+var _ resource.ResourceWithValidateConfig = &AccountMFAResetResource{}
+
+// NewAccountMFAResetResource is a helper function to simplify the provider implementation.
+func NewAccountMFAResetResource() resource.Resource {
+	return &AccountMFAResetResource{}
+}
+
+// AccountMFAResetResource is the resource implementation.
+type AccountMFAResetResource struct {
+	client *bastion.Client
+}
+
+// AccountMFAResetResourceModel describes the resource data model.
+type AccountMFAResetResourceModel struct {
+	ID                    types.String `tfsdk:"id"`
+	Account               types.String `tfsdk:"account"`
+	TOTP                  types.Bool   `tfsdk:"totp"`
+	Password              types.Bool   `tfsdk:"password"`
+	ResetTrigger          types.String `tfsdk:"reset_trigger"`
+	MFATOTPConfigured     types.Bool   `tfsdk:"mfa_totp_configured"`
+	MFAPasswordConfigured types.Bool   `tfsdk:"mfa_password_configured"`
+}
+
+// Metadata returns the resource type name.
+func (r *AccountMFAResetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_account_mfa_reset"
+}
+
+// Schema defines the schema for the resource.
+func (r *AccountMFAResetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resets the MFA enrollment of a Bastion account, for example when a user lost their TOTP device. " +
+			"The reset happens when the resource is created and every time `reset_trigger` changes. " +
+			"Destroying the resource does not change the account.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The resource identifier (account)",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"account": schema.StringAttribute{
+				MarkdownDescription: "The name of the Bastion account",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"totp": schema.BoolAttribute{
+				MarkdownDescription: "Whether to reset the TOTP enrollment of the account",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"password": schema.BoolAttribute{
+				MarkdownDescription: "Whether to reset the MFA password of the account",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"reset_trigger": schema.StringAttribute{
+				MarkdownDescription: "Arbitrary value, changing it resets the MFA of the account again. A ticket reference works well.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"mfa_totp_configured": schema.BoolAttribute{
+				MarkdownDescription: "Whether the account currently has TOTP configured",
+				Computed:            true,
+			},
+			"mfa_password_configured": schema.BoolAttribute{
+				MarkdownDescription: "Whether the account currently has an MFA password configured",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure adds the bastion client to the resource.
+func (r *AccountMFAResetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*bastion.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *bastion.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ValidateConfig validates the resource configuration.
+func (r *AccountMFAResetResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config AccountMFAResetResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.TOTP.IsUnknown() || config.Password.IsUnknown() {
+		return
+	}
+
+	if !config.TOTP.ValueBool() && !config.Password.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("totp"),
+			"Missing Configuration",
+			"At least one of 'totp' or 'password' must be true.",
+		)
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *AccountMFAResetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan AccountMFAResetResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.TOTP.ValueBool() {
+		err := r.client.AccountMFAResetTOTP(plan.Account.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Resetting Account TOTP",
+				fmt.Sprintf("Could not reset TOTP of account %s: %s", plan.Account.ValueString(), err.Error()),
+			)
+			return
+		}
+	}
+
+	if plan.Password.ValueBool() {
+		err := r.client.AccountMFAResetPassword(plan.Account.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Resetting Account MFA Password",
+				fmt.Sprintf("Could not reset MFA password of account %s: %s", plan.Account.ValueString(), err.Error()),
+			)
+			return
+		}
+	}
+
+	account, err := r.client.AccountInfo(plan.Account.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Account Information",
+			fmt.Sprintf("Could not read account %s: %s", plan.Account.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	plan.ID = plan.Account
+	plan.MFATOTPConfigured = types.BoolValue(account.MFATOTPConfigured.Bool())
+	plan.MFAPasswordConfigured = types.BoolValue(account.MFAPasswordConfigured.Bool())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *AccountMFAResetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state AccountMFAResetResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	account, err := r.client.AccountInfo(state.Account.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Account Information",
+			fmt.Sprintf("Could not read account %s: %s", state.Account.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	// Re-enrolling after the reset is expected, so this never plans a new reset
+	state.MFATOTPConfigured = types.BoolValue(account.MFATOTPConfigured.Bool())
+	state.MFAPasswordConfigured = types.BoolValue(account.MFAPasswordConfigured.Bool())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *AccountMFAResetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Since all attributes require replacement, this should never be called
+	resp.Diagnostics.AddError(
+		"Update Not Supported",
+		"Account MFA resets cannot be updated. This is a bug in the provider.",
+	)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *AccountMFAResetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// A reset cannot be undone, removing the resource from the state is enough
+}
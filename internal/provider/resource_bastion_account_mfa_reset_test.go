@@ -0,0 +1,103 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/adfinis/terraform-provider-bastion/internal/provider/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccAccountMFAResetResource(t *testing.T) {
+	err := testutils.CreateAccount("testmfareset1")
+	if err != nil {
+		t.Errorf("Unable to create test account: %s", err)
+	}
+
+	t.Cleanup(func() {
+		err := testutils.DeleteAccount("testmfareset1")
+		if err != nil {
+			t.Errorf("Unable to delete test account: %s", err)
+		}
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAccountMFAResetResourceConfig("testmfareset1", "INC-1"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"bastion_account_mfa_reset.test",
+						tfjsonpath.New("id"),
+						knownvalue.StringExact("testmfareset1"),
+					),
+					statecheck.ExpectKnownValue(
+						"bastion_account_mfa_reset.test",
+						tfjsonpath.New("mfa_totp_configured"),
+						knownvalue.Bool(false),
+					),
+					statecheck.ExpectKnownValue(
+						"bastion_account_mfa_reset.test",
+						tfjsonpath.New("mfa_password_configured"),
+						knownvalue.Bool(false),
+					),
+				},
+			},
+			// Changing the trigger resets again
+			{
+				Config: testAccAccountMFAResetResourceConfig("testmfareset1", "INC-2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("bastion_account_mfa_reset.test", plancheck.ResourceActionReplace),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"bastion_account_mfa_reset.test",
+						tfjsonpath.New("reset_trigger"),
+						knownvalue.StringExact("INC-2"),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccAccountMFAResetResource_NothingToReset(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "bastion_account_mfa_reset" "test" {
+  account = "testmfareset2"
+}
+`,
+				ExpectError: regexp.MustCompile("At least one of 'totp' or 'password' must be true"),
+			},
+		},
+	})
+}
+
+func testAccAccountMFAResetResourceConfig(account, trigger string) string {
+	return providerConfig + fmt.Sprintf(`
+resource "bastion_account_mfa_reset" "test" {
+  account       = %[1]q
+  totp          = true
+  password      = true
+  reset_trigger = %[2]q
+}
+`, account, trigger)
+}
@@ -0,0 +1,399 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/adfinis/terraform-provider-bastion/internal/provider/customtypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = &AccountPersonalAccessResource{}
+var _ resource.ResourceWithConfigure = &AccountPersonalAccessResource{}
+var _ resource.ResourceWithImportState = &AccountPersonalAccessResource{}
+
+// NewAccountPersonalAccessResource is a helper function to simplify the provider implementation.
+func NewAccountPersonalAccessResource() resource.Resource {
+	return &AccountPersonalAccessResource{}
+}
+
+// AccountPersonalAccessResource is the resource implementation.
+type AccountPersonalAccessResource struct {
+	client *bastion.Client
+}
+
+// AccountPersonalAccessResourceModel describes the resource data model.
+type AccountPersonalAccessResourceModel struct {
+	ID            types.String          `tfsdk:"id"`
+	Account       types.String          `tfsdk:"account"`
+	IP            customtypes.IPAddress `tfsdk:"ip"`
+	Port          types.String          `tfsdk:"port"`
+	User          types.String          `tfsdk:"user"`
+	Protocol      types.String          `tfsdk:"protocol"`
+	ProxyIP       customtypes.IPAddress `tfsdk:"proxy_ip"`
+	ProxyPort     types.String          `tfsdk:"proxy_port"`
+	ProxyUser     types.String          `tfsdk:"proxy_user"`
+	Comment       types.String          `tfsdk:"comment"`
+	ForceKey      types.String          `tfsdk:"force_key"`
+	ForcePassword types.String          `tfsdk:"force_password"`
+	TTL           customtypes.Duration  `tfsdk:"ttl"`
+	RemotePort    types.Int64           `tfsdk:"remote_port"`
+}
+
+// acl returns the attributes the personal access shares with the ACL entries of The Bastion.
+func (m *AccountPersonalAccessResourceModel) acl() accessACL {
+	return accessACL{
+		IP:         &m.IP,
+		Port:       &m.Port,
+		User:       &m.User,
+		Protocol:   &m.Protocol,
+		ProxyIP:    &m.ProxyIP,
+		ProxyPort:  &m.ProxyPort,
+		ProxyUser:  &m.ProxyUser,
+		RemotePort: &m.RemotePort,
+		Comment:    &m.Comment,
+	}
+}
+
+// Metadata returns the resource type name.
+func (r *AccountPersonalAccessResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_account_personal_access"
+}
+
+// Schema defines the schema for the resource.
+func (r *AccountPersonalAccessResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Manages a Bastion account personal access.
+
+Personal accesses use the egress keys of the account, see the ` + "`bastion_account_egress_keys`" + ` data source.
+Some features like proxyjump accesses and port forwardings are only supported when running [The Bastion fork](https://github.com/adfinis-forks/the-bastion) from Adfinis.`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The resource identifier",
+				Computed:            true,
+			},
+			"account": schema.StringAttribute{
+				MarkdownDescription: "The Bastion account to add the personal access to",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ip": schema.StringAttribute{
+				CustomType:          customtypes.IPAddressType{},
+				MarkdownDescription: "IP or subnet of the access target",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					customtypes.IPAddressPlanModifier(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"port": schema.StringAttribute{
+				MarkdownDescription: "Port of the access target, use '*' to allow ssh access to all ports",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user": schema.StringAttribute{
+				MarkdownDescription: "Username for the access, use '*' to allow ssh access for all users. Cannot be used together with `protocol`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("protocol")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"protocol": schema.StringAttribute{
+				MarkdownDescription: "Protocol to grant access for. Valid values are 'sftp', 'scpupload', 'scpdownload', 'rsync', 'portforward'. When set, 'user' must be empty. A base access must already exist for the server.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("user")),
+					stringvalidator.OneOf("sftp", "scpupload", "scpdownload", "rsync", "portforward"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"proxy_ip": schema.StringAttribute{
+				CustomType:          customtypes.IPAddressType{},
+				MarkdownDescription: "IP of the proxy server",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("proxy_port")),
+					stringvalidator.AlsoRequires(path.MatchRoot("proxy_user")),
+				},
+				PlanModifiers: []planmodifier.String{
+					customtypes.IPAddressPlanModifier(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"proxy_port": schema.StringAttribute{
+				MarkdownDescription: "Port of the proxy server",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"proxy_user": schema.StringAttribute{
+				MarkdownDescription: "Username for the proxy server, use '*' to allow all users",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"comment": schema.StringAttribute{
+				MarkdownDescription: "Comment for the access",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"force_key": schema.StringAttribute{
+				MarkdownDescription: "Force a specific SSH key for the access",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"force_password": schema.StringAttribute{
+				MarkdownDescription: "Force a specific password for the access",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ttl": schema.StringAttribute{
+				CustomType:          customtypes.DurationType{},
+				MarkdownDescription: "Time to live for the access, as a number of seconds or a duration like `90m` or `7d`",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					customtypes.DurationPlanModifier(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"remote_port": schema.Int64Attribute{
+				MarkdownDescription: "Remote port forwarded from the target server to The Bastion",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the bastion client to the resource.
+func (r *AccountPersonalAccessResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*bastion.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *bastion.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *AccountPersonalAccessResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan AccountPersonalAccessResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// either user or protocol must exist but not both
+	if (plan.User.IsNull() && plan.Protocol.IsNull()) || (!plan.User.IsNull() && !plan.Protocol.IsNull()) {
+		resp.Diagnostics.AddError(
+			"Error Creating Account Personal Access",
+			"Either 'user' or 'protocol' must be set, but not both.",
+		)
+		return
+	}
+
+	// Build options
+	options := &bastion.AccountAddPersonalAccessOptions{}
+
+	if !plan.ForceKey.IsNull() {
+		options.ForceKey = plan.ForceKey.ValueString()
+	}
+
+	if !plan.ForcePassword.IsNull() {
+		options.ForcePassword = plan.ForcePassword.ValueString()
+	}
+
+	if !plan.Comment.IsNull() {
+		options.Comment = plan.Comment.ValueString()
+	}
+
+	if !plan.TTL.IsNull() {
+		options.TTL = plan.TTL.ValueDuration()
+	}
+
+	if !plan.Protocol.IsNull() {
+		options.Protocol = plan.Protocol.ValueString()
+	}
+
+	// Handle proxy options
+	if !plan.ProxyIP.IsNull() || !plan.ProxyPort.IsNull() || !plan.ProxyUser.IsNull() {
+		options.ProxyOptions = &bastion.ProxyOptions{
+			ProxyHost: plan.ProxyIP.ValueCanonical(),
+			ProxyPort: plan.ProxyPort.ValueString(),
+			ProxyUser: plan.ProxyUser.ValueString(),
+		}
+	}
+
+	if !plan.RemotePort.IsNull() {
+		remotePort := int(plan.RemotePort.ValueInt64())
+		options.RemotePort = &remotePort
+	}
+
+	// Add the personal access
+	access, err := r.client.AccountAddPersonalAccess(
+		plan.Account.ValueString(),
+		plan.IP.ValueCanonical(),
+		plan.Port.ValueString(),
+		plan.User.ValueString(),
+		options,
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Adding Account Personal Access",
+			fmt.Sprintf("Could not add personal access to account %s: %s", plan.Account.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	plan.IP = customtypes.NewIPAddressValue(access.IP)
+	flattenACL(plan.acl(), access)
+
+	// Generate ID
+	plan.ID = types.StringValue(generatePersonalAccessID(&plan))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *AccountPersonalAccessResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state AccountPersonalAccessResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// List all accesses of the account, only personal ones are relevant
+	accesses, err := r.client.AccountListAccesses(state.Account.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Account Accesses",
+			fmt.Sprintf("Could not read accesses for account %s: %s", state.Account.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	var found *bastion.ACL
+	for _, access := range accesses {
+		if access.AccessType != "personal" {
+			continue
+		}
+		for i := range access.ACL {
+			if matchesACL(state.acl(), &access.ACL[i]) {
+				found = &access.ACL[i]
+				break
+			}
+		}
+		if found != nil {
+			break
+		}
+	}
+
+	if found == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Update state from API response
+	state.IP = customtypes.NewIPAddressValue(found.IP)
+	flattenACL(state.acl(), found)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *AccountPersonalAccessResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.AddError(
+		"Update Not Supported",
+		"Account personal accesses cannot be updated. This is a bug in the provider.",
+	)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *AccountPersonalAccessResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state AccountPersonalAccessResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Build proxy options if needed
+	var proxyOpts *bastion.ProxyOptions
+	if !state.ProxyIP.IsNull() {
+		proxyOpts = &bastion.ProxyOptions{
+			ProxyHost: state.ProxyIP.ValueCanonical(),
+			ProxyPort: state.ProxyPort.ValueString(),
+			ProxyUser: state.ProxyUser.ValueString(),
+		}
+	}
+
+	err := r.client.AccountDelPersonalAccess(
+		state.Account.ValueString(),
+		state.IP.ValueCanonical(),
+		state.Port.ValueString(),
+		state.User.ValueString(),
+		state.Protocol.ValueString(),
+		proxyOpts,
+		state.RemotePort.ValueInt64Pointer(),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Account Personal Access",
+			fmt.Sprintf("Could not delete personal access from account %s: %s", state.Account.ValueString(), err.Error()),
+		)
+		return
+	}
+}
+
+// ImportState imports the resource state.
+func (r *AccountPersonalAccessResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importAccessACL(ctx, req.ID, []string{"account"}, resp)
+}
+
+// generatePersonalAccessID generates a unique ID for a personal access.
+func generatePersonalAccessID(model *AccountPersonalAccessResourceModel) string {
+	target := formatIPForID(model.IP.ValueString())
+	return accessACLID([]string{model.Account.ValueString()}, target, model.acl())
+}
@@ -0,0 +1,158 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/adfinis/terraform-provider-bastion/internal/provider/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccAccountPersonalAccessResource(t *testing.T) {
+	err := testutils.CreateAccount("testpersacc1")
+	if err != nil {
+		t.Errorf("Unable to create test account: %s", err)
+	}
+
+	t.Cleanup(func() {
+		err := testutils.DeleteAccount("testpersacc1")
+		if err != nil {
+			t.Errorf("Unable to delete test account: %s", err)
+		}
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAccountPersonalAccessResourceConfig("testpersacc1", "192.168.10.100", "22", "root", "personal access"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"bastion_account_personal_access.test",
+						tfjsonpath.New("account"),
+						knownvalue.StringExact("testpersacc1"),
+					),
+					statecheck.ExpectKnownValue(
+						"bastion_account_personal_access.test",
+						tfjsonpath.New("ip"),
+						knownvalue.StringExact("192.168.10.100"),
+					),
+					statecheck.ExpectKnownValue(
+						"bastion_account_personal_access.test",
+						tfjsonpath.New("user"),
+						knownvalue.StringExact("root"),
+					),
+					statecheck.ExpectKnownValue(
+						"bastion_account_personal_access.test",
+						tfjsonpath.New("comment"),
+						knownvalue.StringExact("personal access"),
+					),
+					statecheck.ExpectKnownValue(
+						"bastion_account_personal_access.test",
+						tfjsonpath.New("id"),
+						knownvalue.StringExact("testpersacc1:192.168.10.100:22:root"),
+					),
+				},
+			},
+			// ImportState testing
+			{
+				ResourceName:      "bastion_account_personal_access.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     "testpersacc1:192.168.10.100:22:root",
+			},
+			// An equivalent spelling of the IP does not replace the access
+			{
+				Config: testAccAccountPersonalAccessResourceConfig("testpersacc1", "192.168.10.100/32", "22", "root", "personal access"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccAccountPersonalAccessResource_Protocol(t *testing.T) {
+	err := testutils.CreateAccount("testpersacc2")
+	if err != nil {
+		t.Errorf("Unable to create test account: %s", err)
+	}
+
+	t.Cleanup(func() {
+		err := testutils.DeleteAccount("testpersacc2")
+		if err != nil {
+			t.Errorf("Unable to delete test account: %s", err)
+		}
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccountPersonalAccessResourceConfigWithProtocol("testpersacc2", "192.168.10.101", "22", "sftp"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"bastion_account_personal_access.protocol",
+						tfjsonpath.New("protocol"),
+						knownvalue.StringExact("sftp"),
+					),
+					statecheck.ExpectKnownValue(
+						"bastion_account_personal_access.protocol",
+						tfjsonpath.New("user"),
+						knownvalue.Null(),
+					),
+					statecheck.ExpectKnownValue(
+						"bastion_account_personal_access.protocol",
+						tfjsonpath.New("id"),
+						knownvalue.StringExact("testpersacc2:192.168.10.101:22::sftp"),
+					),
+				},
+			},
+		},
+	})
+}
+
+// testAccAccountPersonalAccessResourceConfig generates the Terraform configuration for testing.
+func testAccAccountPersonalAccessResourceConfig(account, ip, port, user, comment string) string {
+	return providerConfig + fmt.Sprintf(`
+resource "bastion_account_personal_access" "test" {
+  account = %[1]q
+  ip      = %[2]q
+  port    = %[3]q
+  user    = %[4]q
+  comment = %[5]q
+}
+`, account, ip, port, user, comment)
+}
+
+// testAccAccountPersonalAccessResourceConfigWithProtocol generates config with a base and a protocol access.
+func testAccAccountPersonalAccessResourceConfigWithProtocol(account, ip, port, protocol string) string {
+	return providerConfig + fmt.Sprintf(`
+resource "bastion_account_personal_access" "base" {
+  account = %[1]q
+  ip      = %[2]q
+  port    = %[3]q
+  user    = "root"
+}
+
+resource "bastion_account_personal_access" "protocol" {
+  account    = %[1]q
+  ip         = %[2]q
+  port       = %[3]q
+  protocol   = %[4]q
+  depends_on = [bastion_account_personal_access.base]
+}
+`, account, ip, port, protocol)
+}
@@ -5,11 +5,15 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
+	"time"
 
+	"github.com/adfinis/terraform-provider-bastion/bastion"
 	"github.com/adfinis/terraform-provider-bastion/internal/provider/testutils"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 )
@@ -712,6 +716,241 @@ resource "bastion_account" "test" {
 	return config
 }
 
+func TestAccAccountResource_Drift(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccountResourceConfigWithModifyOptions("testaccount12", false, 9943, map[string]any{
+					"egress_strict_host_key_checking": "yes",
+				}),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"bastion_account.test",
+						tfjsonpath.New("uid"),
+						knownvalue.Int64Exact(9943),
+					),
+					statecheck.ExpectKnownValue(
+						"bastion_account.test",
+						tfjsonpath.New("egress_session_multiplexing"),
+						knownvalue.StringExact("default"),
+					),
+					statecheck.ExpectKnownValue(
+						"bastion_account.test",
+						tfjsonpath.New("pubkey_auth_optional"),
+						knownvalue.Bool(false),
+					),
+				},
+			},
+			// Settings changed outside of Terraform show up in the plan
+			{
+				PreConfig: func() {
+					policy := bastion.EgressStrictHostKeyCheckingNo
+					pubkeyAuthOptional := true
+					err := testutils.TestBastionClient.ModifyAccount("testaccount12", &bastion.ModifyAccountOptions{
+						EgressStrictHostKeyChecking: &policy,
+						PubkeyAuthOptional:          &pubkeyAuthOptional,
+					})
+					if err != nil {
+						t.Errorf("Unable to modify test account: %s", err)
+					}
+				},
+				Config: testAccAccountResourceConfigWithModifyOptions("testaccount12", false, 9943, map[string]any{
+					"egress_strict_host_key_checking": "yes",
+				}),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Applying the configuration again reverts the drift
+			{
+				Config: testAccAccountResourceConfigWithModifyOptions("testaccount12", false, 9943, map[string]any{
+					"egress_strict_host_key_checking": "yes",
+				}),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"bastion_account.test",
+						tfjsonpath.New("egress_strict_host_key_checking"),
+						knownvalue.StringExact("yes"),
+					),
+					statecheck.ExpectKnownValue(
+						"bastion_account.test",
+						tfjsonpath.New("pubkey_auth_optional"),
+						knownvalue.Bool(false),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccAccountResource_Freeze(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccountResourceConfigWithModifyOptions("testaccount13", true, 0, map[string]any{}),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"bastion_account.test",
+						tfjsonpath.New("frozen"),
+						knownvalue.Bool(false),
+					),
+				},
+			},
+			// Freeze the account in place
+			{
+				Config: testAccAccountResourceConfigWithModifyOptions("testaccount13", true, 0, map[string]any{
+					"frozen":        true,
+					"freeze_reason": "compromised key",
+				}),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("bastion_account.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"bastion_account.test",
+						tfjsonpath.New("frozen"),
+						knownvalue.Bool(true),
+					),
+					statecheck.ExpectKnownValue(
+						"bastion_account.test",
+						tfjsonpath.New("freeze_reason"),
+						knownvalue.StringExact("compromised key"),
+					),
+				},
+			},
+			// Unfreezing outside of Terraform shows up in the plan
+			{
+				PreConfig: func() {
+					err := testutils.TestBastionClient.AccountUnfreeze("testaccount13")
+					if err != nil {
+						t.Errorf("Unable to unfreeze test account: %s", err)
+					}
+				},
+				Config: testAccAccountResourceConfigWithModifyOptions("testaccount13", true, 0, map[string]any{
+					"frozen":        true,
+					"freeze_reason": "compromised key",
+				}),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Unfreeze the account in place
+			{
+				Config: testAccAccountResourceConfigWithModifyOptions("testaccount13", true, 0, map[string]any{}),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("bastion_account.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"bastion_account.test",
+						tfjsonpath.New("frozen"),
+						knownvalue.Bool(false),
+					),
+					statecheck.ExpectKnownValue(
+						"bastion_account.test",
+						tfjsonpath.New("freeze_reason"),
+						knownvalue.Null(),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccAccountResource_Expiry(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccountResourceConfigWithModifyOptions("testaccount14", true, 0, map[string]any{
+					"auto_unexpire": true,
+				}),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"bastion_account.test",
+						tfjsonpath.New("auto_unexpire"),
+						knownvalue.Bool(true),
+					),
+					statecheck.ExpectKnownValue(
+						"bastion_account.test",
+						tfjsonpath.New("is_expired"),
+						knownvalue.Bool(false),
+					),
+					statecheck.ExpectKnownValue(
+						"bastion_account.test",
+						tfjsonpath.New("is_active"),
+						knownvalue.Bool(true),
+					),
+					// the account never connected
+					statecheck.ExpectKnownValue(
+						"bastion_account.test",
+						tfjsonpath.New("last_activity"),
+						knownvalue.Null(),
+					),
+				},
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "bastion_account.test",
+				ImportStateId:                        "testaccount14",
+				ImportStateVerifyIdentifierAttribute: "account",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				// auto_unexpire is not stored on The Bastion
+				ImportStateVerifyIgnore: []string{"uid_auto", "auto_unexpire"},
+			},
+		},
+	})
+}
+
+func TestAccAccountResource_ExpiresAt(t *testing.T) {
+	expiresAt := time.Now().Add(2 * time.Hour).UTC().Truncate(time.Second).Format(time.RFC3339)
+	past := time.Now().Add(-time.Hour).UTC().Truncate(time.Second).Format(time.RFC3339)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Timestamps in the past are rejected at plan time
+			{
+				Config: testAccAccountResourceConfigWithModifyOptions("testaccount15", true, 0, map[string]any{
+					"expires_at": past,
+				}),
+				ExpectError: regexp.MustCompile("lies in the past"),
+			},
+			{
+				Config: testAccAccountResourceConfigWithModifyOptions("testaccount15", true, 0, map[string]any{
+					"expires_at": expiresAt,
+				}),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"bastion_account.test",
+						tfjsonpath.New("expires_at"),
+						knownvalue.StringExact(expiresAt),
+					),
+				},
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "bastion_account.test",
+				ImportStateId:                        "testaccount15",
+				ImportStateVerifyIdentifierAttribute: "account",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				// the expiry reported by The Bastion can be off by a few seconds
+				ImportStateVerifyIgnore: []string{"uid_auto", "auto_unexpire", "expires_at"},
+			},
+		},
+	})
+}
+
 // testAccAccountResourceConfigWithUID generates config with a specific UID.
 func testAccAccountResourceConfigWithUID(accountName string, uid int) string {
 	config := providerConfig
@@ -788,6 +1027,22 @@ resource "bastion_account" "test" {
 		resourceConfig += fmt.Sprintf("  pubkey_auth_optional = %t\n", pubkeyAuthOptional)
 	}
 
+	if frozen, ok := options["frozen"].(bool); ok {
+		resourceConfig += fmt.Sprintf("  frozen = %t\n", frozen)
+	}
+
+	if freezeReason, ok := options["freeze_reason"].(string); ok {
+		resourceConfig += fmt.Sprintf("  freeze_reason = %q\n", freezeReason)
+	}
+
+	if autoUnexpire, ok := options["auto_unexpire"].(bool); ok {
+		resourceConfig += fmt.Sprintf("  auto_unexpire = %t\n", autoUnexpire)
+	}
+
+	if expiresAt, ok := options["expires_at"].(string); ok {
+		resourceConfig += fmt.Sprintf("  expires_at = %q\n", expiresAt)
+	}
+
 	resourceConfig += "}\n"
 	config += resourceConfig
 
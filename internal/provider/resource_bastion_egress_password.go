@@ -0,0 +1,262 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = &EgressPasswordResource{}
+var _ resource.ResourceWithImportState = &EgressPasswordResource{}
+var _ resource.ResourceWithConfigure = &EgressPasswordResource{}
+
+// egressPasswordOwner describes the kind of Bastion entity an EgressPasswordResource generates passwords for.
+type egressPasswordOwner struct {
+	// kind is the name of the owner kind, also used as the name of its attribute, e.g. "account"
+	kind     string
+	generate func(client *bastion.Client, name string, size int) (*bastion.EgressPassword, error)
+	list     func(client *bastion.Client, name string) ([]bastion.EgressPassword, error)
+}
+
+var accountEgressPasswordOwner = egressPasswordOwner{
+	kind:     "account",
+	generate: (*bastion.Client).AccountGeneratePassword,
+	list:     (*bastion.Client).AccountListPasswords,
+}
+
+var groupEgressPasswordOwner = egressPasswordOwner{
+	kind:     "group",
+	generate: (*bastion.Client).GroupGeneratePassword,
+	list:     (*bastion.Client).GroupListPasswords,
+}
+
+// NewAccountEgressPasswordResource is a helper function to simplify the provider implementation.
+func NewAccountEgressPasswordResource() resource.Resource {
+	return &EgressPasswordResource{owner: accountEgressPasswordOwner}
+}
+
+// NewGroupEgressPasswordResource is a helper function to simplify the provider implementation.
+func NewGroupEgressPasswordResource() resource.Resource {
+	return &EgressPasswordResource{owner: groupEgressPasswordOwner}
+}
+
+// EgressPasswordResource is the resource implementation, generating the egress password of an account or a group.
+// As the name of the owner attribute depends on its kind, the attributes are accessed by path instead of a model.
+type EgressPasswordResource struct {
+	client *bastion.Client
+	owner  egressPasswordOwner
+}
+
+// Metadata returns the resource type name.
+func (r *EgressPasswordResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.owner.kind + "_egress_password"
+}
+
+// Schema defines the schema for the resource.
+func (r *EgressPasswordResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf("Generates an egress password for a Bastion %s, used to connect to servers only accepting password authentication. ", r.owner.kind) +
+			"A new password is generated when the resource is created and every time `rotation_trigger` changes, the previous one is kept as a fallback. " +
+			"The Bastion never reveals the plaintext password, only its hashes which can be deployed on the servers or referenced by `force_password`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("The resource identifier (%s)", r.owner.kind),
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			r.owner.kind: schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("The name of the Bastion %s", r.owner.kind),
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"size": schema.Int64Attribute{
+				MarkdownDescription: "Length of the generated password, between 8 and 127. Defaults to the size chosen by The Bastion.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.Between(8, 127),
+				},
+			},
+			"rotation_trigger": schema.StringAttribute{
+				MarkdownDescription: "Arbitrary value, changing it generates a new password",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"hashes": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Hashes of the generated password, keyed by hash type (e.g. `sha512crypt`)",
+				Computed:            true,
+			},
+			"is_current": schema.BoolAttribute{
+				MarkdownDescription: "Whether the generated password is still the current one, false once it has been rotated outside of Terraform",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure adds the bastion client to the resource.
+func (r *EgressPasswordResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*bastion.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *bastion.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *EgressPasswordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var owner types.String
+	var size types.Int64
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(r.owner.kind), &owner)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("size"), &size)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.owner.generate(r.client, owner.ValueString(), int(size.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Error Generating %s Egress Password", titleCase(r.owner.kind)),
+			fmt.Sprintf("Could not generate egress password for %s %s: %s", r.owner.kind, owner.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	passwords, err := r.owner.list(r.client, owner.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Error Reading %s Egress Passwords", titleCase(r.owner.kind)),
+			fmt.Sprintf("Could not list egress passwords of %s %s: %s", r.owner.kind, owner.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	current := findEgressPassword(passwords, nil)
+	if current < 0 {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Error Reading %s Egress Passwords", titleCase(r.owner.kind)),
+			fmt.Sprintf("The generated egress password of %s %s could not be found", r.owner.kind, owner.ValueString()),
+		)
+		return
+	}
+
+	hashes, diags := types.MapValueFrom(ctx, types.StringType, passwords[current].Hashes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.State.Raw = req.Plan.Raw
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), owner)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("hashes"), hashes)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("is_current"), true)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *EgressPasswordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var owner types.String
+	var known map[string]string
+
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(r.owner.kind), &owner)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("hashes"), &known)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	passwords, err := r.owner.list(r.client, owner.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Error Reading %s Egress Passwords", titleCase(r.owner.kind)),
+			fmt.Sprintf("Could not list egress passwords of %s %s: %s", r.owner.kind, owner.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	index := findEgressPassword(passwords, known)
+	if index < 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	hashes, diags := types.MapValueFrom(ctx, types.StringType, passwords[index].Hashes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), owner)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("hashes"), hashes)...)
+	// The Bastion lists the current password first, followed by the previous ones
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("is_current"), index == 0)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *EgressPasswordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Since all attributes require replacement, this should never be called
+	resp.Diagnostics.AddError(
+		"Update Not Supported",
+		fmt.Sprintf("%s egress passwords cannot be updated. This is a bug in the provider.", titleCase(r.owner.kind)),
+	)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *EgressPasswordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// The Bastion cannot delete egress passwords, they are only replaced by newer ones
+}
+
+// ImportState imports the resource state.
+func (r *EgressPasswordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root(r.owner.kind), req, resp)
+}
+
+// findEgressPassword returns the index of the password having one of the given hashes,
+// or of the current password if no hashes are given. It returns -1 if no password matches.
+func findEgressPassword(passwords []bastion.EgressPassword, hashes map[string]string) int {
+	if len(hashes) == 0 {
+		// The Bastion lists the current password first
+		if len(passwords) == 0 {
+			return -1
+		}
+		return 0
+	}
+	for i, password := range passwords {
+		for hashType, hash := range hashes {
+			if password.Hashes[hashType] == hash {
+				return i
+			}
+		}
+	}
+	return -1
+}
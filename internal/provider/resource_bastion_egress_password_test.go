@@ -0,0 +1,206 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/adfinis/terraform-provider-bastion/internal/provider/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccAccountEgressPasswordResource(t *testing.T) {
+	err := testutils.CreateAccount("testaccpwd1")
+	if err != nil {
+		t.Errorf("Unable to create test account: %s", err)
+	}
+
+	t.Cleanup(func() {
+		err := testutils.DeleteAccount("testaccpwd1")
+		if err != nil {
+			t.Errorf("Unable to delete test account: %s", err)
+		}
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAccountEgressPasswordResourceConfig("testaccpwd1"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"bastion_account_egress_password.test",
+						tfjsonpath.New("id"),
+						knownvalue.StringExact("testaccpwd1"),
+					),
+					statecheck.ExpectKnownValue(
+						"bastion_account_egress_password.test",
+						tfjsonpath.New("hashes").AtMapKey("sha512crypt"),
+						knownvalue.NotNull(),
+					),
+				},
+			},
+			// A rotation outside of Terraform keeps the password as a fallback
+			{
+				PreConfig: func() {
+					_, err := testutils.TestBastionClient.AccountGeneratePassword("testaccpwd1", 0)
+					if err != nil {
+						t.Errorf("Unable to rotate egress password: %s", err)
+					}
+				},
+				Config: testAccAccountEgressPasswordResourceConfig("testaccpwd1"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"bastion_account_egress_password.test",
+						tfjsonpath.New("is_current"),
+						knownvalue.Bool(false),
+					),
+				},
+			},
+		},
+	})
+}
+
+func testAccAccountEgressPasswordResourceConfig(account string) string {
+	return providerConfig + fmt.Sprintf(`
+resource "bastion_account_egress_password" "test" {
+  account = %[1]q
+}
+`, account)
+}
+
+func TestAccGroupEgressPasswordResource(t *testing.T) {
+	err := testutils.CreateGroup("testgrouppwd1", "bastionadmin", bastion.ED25519)
+	if err != nil {
+		t.Errorf("Unable to create test group: %s", err)
+	}
+
+	t.Cleanup(func() {
+		err := testutils.DeleteGroup("testgrouppwd1")
+		if err != nil {
+			t.Errorf("Unable to delete test group: %s", err)
+		}
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccGroupEgressPasswordResourceConfig("testgrouppwd1", "1"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"bastion_group_egress_password.test",
+						tfjsonpath.New("id"),
+						knownvalue.StringExact("testgrouppwd1"),
+					),
+					statecheck.ExpectKnownValue(
+						"bastion_group_egress_password.test",
+						tfjsonpath.New("hashes").AtMapKey("sha512crypt"),
+						knownvalue.NotNull(),
+					),
+					statecheck.ExpectKnownValue(
+						"bastion_group_egress_password.test",
+						tfjsonpath.New("is_current"),
+						knownvalue.Bool(true),
+					),
+				},
+			},
+			// Changing the trigger rotates the password
+			{
+				Config: testAccGroupEgressPasswordResourceConfig("testgrouppwd1", "2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("bastion_group_egress_password.test", plancheck.ResourceActionReplace),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"bastion_group_egress_password.test",
+						tfjsonpath.New("is_current"),
+						knownvalue.Bool(true),
+					),
+				},
+			},
+			// ImportState testing
+			{
+				ResourceName:      "bastion_group_egress_password.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     "testgrouppwd1",
+				// size and rotation_trigger are not stored on The Bastion
+				ImportStateVerifyIgnore: []string{"size", "rotation_trigger"},
+			},
+		},
+	})
+}
+
+func testAccGroupEgressPasswordResourceConfig(group, trigger string) string {
+	return providerConfig + fmt.Sprintf(`
+resource "bastion_group_egress_password" "test" {
+  group            = %[1]q
+  size             = 32
+  rotation_trigger = %[2]q
+}
+`, group, trigger)
+}
+
+func TestFindEgressPassword(t *testing.T) {
+	// The Bastion lists the current password first, its ID is not necessarily 0
+	passwords := []bastion.EgressPassword{
+		{ID: 3, Hashes: map[string]string{"sha512crypt": "$6$current"}},
+		{ID: 2, Hashes: map[string]string{"sha512crypt": "$6$previous"}},
+	}
+
+	testCases := []struct {
+		name      string
+		passwords []bastion.EgressPassword
+		hashes    map[string]string
+		expected  int
+	}{
+		{
+			name:      "current without hashes",
+			passwords: passwords,
+			expected:  0,
+		},
+		{
+			name:      "current by hash",
+			passwords: passwords,
+			hashes:    map[string]string{"sha512crypt": "$6$current"},
+			expected:  0,
+		},
+		{
+			name:      "rotated by hash",
+			passwords: passwords,
+			hashes:    map[string]string{"sha512crypt": "$6$previous"},
+			expected:  1,
+		},
+		{
+			name:      "unknown hash",
+			passwords: passwords,
+			hashes:    map[string]string{"sha512crypt": "$6$other"},
+			expected:  -1,
+		},
+		{
+			name:     "no passwords",
+			expected: -1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, findEgressPassword(tc.passwords, tc.hashes))
+		})
+	}
+}
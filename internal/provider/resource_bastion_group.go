@@ -7,11 +7,12 @@ import (
 	"context"
 	"fmt"
 	"slices"
-	"strconv"
 
 	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/adfinis/terraform-provider-bastion/internal/provider/customtypes"
 	"github.com/adfinis/terraform-provider-bastion/internal/provider/utils"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -40,18 +41,19 @@ type GroupResource struct {
 
 // GroupResourceModel describes the resource data model.
 type GroupResourceModel struct {
-	Group           types.String `tfsdk:"group"`
-	Owner           types.String `tfsdk:"owner"`
-	KeyAlgo         types.String `tfsdk:"key_algo"`
-	MFARequired     types.String `tfsdk:"mfa_required"`
-	IdleLockTimeout types.Int64  `tfsdk:"idle_lock_timeout"`
-	IdleKillTimeout types.Int64  `tfsdk:"idle_kill_timeout"`
-	GuestTtlLimit   types.Int64  `tfsdk:"guest_ttl_limit"`
-	TryPersonalKeys types.Bool   `tfsdk:"try_personal_keys"`
-	Owners          types.List   `tfsdk:"owners"`
-	Members         types.List   `tfsdk:"members"`
-	Gatekeepers     types.List   `tfsdk:"gatekeepers"`
-	ACLKeepers      types.List   `tfsdk:"aclkeepers"`
+	Group               types.String         `tfsdk:"group"`
+	Owner               types.String         `tfsdk:"owner"`
+	OwnerChangeStrategy types.String         `tfsdk:"owner_change_strategy"`
+	KeyAlgo             types.String         `tfsdk:"key_algo"`
+	MFARequired         types.String         `tfsdk:"mfa_required"`
+	IdleLockTimeout     customtypes.Duration `tfsdk:"idle_lock_timeout"`
+	IdleKillTimeout     customtypes.Duration `tfsdk:"idle_kill_timeout"`
+	GuestTtlLimit       customtypes.Duration `tfsdk:"guest_ttl_limit"`
+	TryPersonalKeys     types.Bool           `tfsdk:"try_personal_keys"`
+	Owners              types.List           `tfsdk:"owners"`
+	Members             types.List           `tfsdk:"members"`
+	Gatekeepers         types.List           `tfsdk:"gatekeepers"`
+	ACLKeepers          types.List           `tfsdk:"aclkeepers"`
 }
 
 // Metadata returns the resource type name.
@@ -72,9 +74,22 @@ func (r *GroupResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				},
 			},
 			"owner": schema.StringAttribute{
-				MarkdownDescription: "The owner of the Bastion group. Changing this will transfer ownership to the new account.",
+				MarkdownDescription: "The owner of the Bastion group. Changing this will transfer ownership to the new account, see `owner_change_strategy`.",
 				Required:            true,
 			},
+			"owner_change_strategy": schema.StringAttribute{
+				MarkdownDescription: "How ownership is transferred when `owner` changes. " +
+					"`transmit` uses The Bastion's groupTransmitOwnership, which transmits the ownership of the account the provider connects with. " +
+					"When the current owner is another account, it falls back to `add_then_remove` with a warning. " +
+					"`add_then_remove` adds the new owner then removes the old one, the new owner is removed again if the old one cannot be. " +
+					"`add_only` adds the new owner and keeps the old one. Defaults to `transmit`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(ownerChangeTransmit),
+				Validators: []validator.String{
+					stringvalidator.OneOf(ownerChangeTransmit, ownerChangeAddThenRemove, ownerChangeAddOnly),
+				},
+			},
 			"key_algo": schema.StringAttribute{
 				MarkdownDescription: "The SSH key algorithm for the group's initial key. Valid values: ed25519, rsa2048, rsa4096, rsa8192, ecdsa256, ecdsa384, ecdsa521. Defaults to ed25519. This value is only used during creation and cannot be changed afterward.",
 				Optional:            true,
@@ -95,17 +110,32 @@ func (r *GroupResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					stringvalidator.OneOf("totp", "any", "none", "password"),
 				},
 			},
-			"idle_lock_timeout": schema.Int64Attribute{
-				MarkdownDescription: "Idle lock timeout in seconds. After this duration of inactivity, the session will be locked.",
+			"idle_lock_timeout": schema.StringAttribute{
+				CustomType:          customtypes.DurationType{},
+				MarkdownDescription: "Idle lock timeout, as a number of seconds or a duration like `90m`. After this duration of inactivity, the session will be locked.",
 				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					customtypes.DurationPlanModifier(),
+				},
 			},
-			"idle_kill_timeout": schema.Int64Attribute{
-				MarkdownDescription: "Idle kill timeout in seconds. After this duration of inactivity, the session will be terminated.",
+			"idle_kill_timeout": schema.StringAttribute{
+				CustomType:          customtypes.DurationType{},
+				MarkdownDescription: "Idle kill timeout, as a number of seconds or a duration like `90m`. After this duration of inactivity, the session will be terminated.",
 				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					customtypes.DurationPlanModifier(),
+				},
 			},
-			"guest_ttl_limit": schema.Int64Attribute{
-				MarkdownDescription: "Maximum TTL (time to live) for guest accesses in seconds.",
+			"guest_ttl_limit": schema.StringAttribute{
+				CustomType:          customtypes.DurationType{},
+				MarkdownDescription: "Maximum TTL (time to live) for guest accesses, as a number of seconds or a duration like `7d`.",
 				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					customtypes.DurationPlanModifier(),
+				},
 			},
 			"try_personal_keys": schema.BoolAttribute{
 				MarkdownDescription: "Whether to try personal ssh keys for group accesses. Defaults to false.",
@@ -194,17 +224,17 @@ func (r *GroupResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	if !plan.IdleLockTimeout.IsNull() {
-		modifyOpts.IdleLockTimeout = utils.ToPtr(fmt.Sprintf("%d", plan.IdleLockTimeout.ValueInt64()))
+		modifyOpts.IdleLockTimeout = utils.ToPtr(plan.IdleLockTimeout.ValueDuration())
 		needsModify = true
 	}
 
 	if !plan.IdleKillTimeout.IsNull() {
-		modifyOpts.IdleKillTimeout = utils.ToPtr(fmt.Sprintf("%d", plan.IdleKillTimeout.ValueInt64()))
+		modifyOpts.IdleKillTimeout = utils.ToPtr(plan.IdleKillTimeout.ValueDuration())
 		needsModify = true
 	}
 
 	if !plan.GuestTtlLimit.IsNull() {
-		modifyOpts.GuestTtlLimit = utils.ToPtr(fmt.Sprintf("%d", plan.GuestTtlLimit.ValueInt64()))
+		modifyOpts.GuestTtlLimit = utils.ToPtr(plan.GuestTtlLimit.ValueDuration())
 		needsModify = true
 	}
 
@@ -287,39 +317,15 @@ func (r *GroupResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	if group.IdleLockTimeout != nil {
-		idleLockTimeout, err := strconv.ParseInt(*group.IdleLockTimeout, 10, 64)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error Parsing Idle Lock Timeout",
-				fmt.Sprintf("Could not parse idle lock timeout for group %s: %s", plan.Group.ValueString(), err.Error()),
-			)
-			return
-		}
-		plan.IdleLockTimeout = types.Int64Value(idleLockTimeout)
+		plan.IdleLockTimeout = customtypes.NewDurationValue(*group.IdleLockTimeout)
 	}
 
 	if group.IdleKillTimeout != nil {
-		idleKillTimeout, err := strconv.ParseInt(*group.IdleKillTimeout, 10, 64)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error Parsing Idle Kill Timeout",
-				fmt.Sprintf("Could not parse idle kill timeout for group %s: %s", plan.Group.ValueString(), err.Error()),
-			)
-			return
-		}
-		plan.IdleKillTimeout = types.Int64Value(idleKillTimeout)
+		plan.IdleKillTimeout = customtypes.NewDurationValue(*group.IdleKillTimeout)
 	}
 
 	if group.GuestTtlLimit != nil {
-		guestTtlLimit, err := strconv.ParseInt(*group.GuestTtlLimit, 10, 64)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error Parsing Guest TTL Limit",
-				fmt.Sprintf("Could not parse guest TTL limit for group %s: %s", plan.Group.ValueString(), err.Error()),
-			)
-			return
-		}
-		plan.GuestTtlLimit = types.Int64Value(guestTtlLimit)
+		plan.GuestTtlLimit = customtypes.NewDurationValue(*group.GuestTtlLimit)
 	}
 
 	if group.TryPersonalKeys != nil {
@@ -349,6 +355,11 @@ func (r *GroupResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	state.Group = types.StringValue(group.Group)
 
+	// owner_change_strategy is not known after an import
+	if state.OwnerChangeStrategy.IsNull() {
+		state.OwnerChangeStrategy = types.StringValue(ownerChangeTransmit)
+	}
+
 	// Verify that the configured owner is still in the owners list
 	// If not found or no owner configured, use the first owner from the list
 	if !slices.Contains(group.Owners, state.Owner.ValueString()) && len(group.Owners) > 0 {
@@ -388,39 +399,15 @@ func (r *GroupResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	if group.IdleLockTimeout != nil {
-		idleLockTimeout, err := strconv.ParseInt(*group.IdleLockTimeout, 10, 64)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error Parsing Idle Lock Timeout",
-				fmt.Sprintf("Could not parse idle lock timeout for group %s: %s", state.Group.ValueString(), err.Error()),
-			)
-			return
-		}
-		state.IdleLockTimeout = types.Int64Value(idleLockTimeout)
+		state.IdleLockTimeout = customtypes.NewDurationValue(*group.IdleLockTimeout)
 	}
 
 	if group.IdleKillTimeout != nil {
-		idleKillTimeout, err := strconv.ParseInt(*group.IdleKillTimeout, 10, 64)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error Parsing Idle Kill Timeout",
-				fmt.Sprintf("Could not parse idle kill timeout for group %s: %s", state.Group.ValueString(), err.Error()),
-			)
-			return
-		}
-		state.IdleKillTimeout = types.Int64Value(idleKillTimeout)
+		state.IdleKillTimeout = customtypes.NewDurationValue(*group.IdleKillTimeout)
 	}
 
 	if group.GuestTtlLimit != nil {
-		guestTtlLimit, err := strconv.ParseInt(*group.GuestTtlLimit, 10, 64)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error Parsing Guest TTL Limit",
-				fmt.Sprintf("Could not parse guest TTL limit for group %s: %s", state.Group.ValueString(), err.Error()),
-			)
-			return
-		}
-		state.GuestTtlLimit = types.Int64Value(guestTtlLimit)
+		state.GuestTtlLimit = customtypes.NewDurationValue(*group.GuestTtlLimit)
 	}
 
 	if group.TryPersonalKeys != nil {
@@ -445,23 +432,8 @@ func (r *GroupResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	if !plan.Owner.Equal(state.Owner) {
-		// Add the new owner
-		err := r.client.GroupAddOwner(plan.Group.ValueString(), plan.Owner.ValueString())
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error Adding New Group Owner",
-				fmt.Sprintf("Could not add %s as owner of group %s: %s", plan.Owner.ValueString(), plan.Group.ValueString(), err.Error()),
-			)
-			return
-		}
-
-		// Remove the old owner
-		err = r.client.GroupRemoveOwner(plan.Group.ValueString(), state.Owner.ValueString())
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error Removing Old Group Owner",
-				fmt.Sprintf("Could not remove %s as owner of group %s: %s", state.Owner.ValueString(), plan.Group.ValueString(), err.Error()),
-			)
+		resp.Diagnostics.Append(r.changeOwner(plan.Group.ValueString(), state.Owner.ValueString(), plan.Owner.ValueString(), plan.OwnerChangeStrategy.ValueString())...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
 	}
@@ -487,27 +459,27 @@ func (r *GroupResource) Update(ctx context.Context, req resource.UpdateRequest,
 		if !plan.IdleLockTimeout.Equal(state.IdleLockTimeout) {
 			mustModify = true
 			if plan.IdleLockTimeout.IsNull() {
-				modifyOpts.IdleLockTimeout = utils.ToPtr("-1")
+				modifyOpts.IdleLockTimeout = utils.ToPtr(bastion.Duration(-1))
 			} else {
-				modifyOpts.IdleLockTimeout = utils.ToPtr(fmt.Sprintf("%d", plan.IdleLockTimeout.ValueInt64()))
+				modifyOpts.IdleLockTimeout = utils.ToPtr(plan.IdleLockTimeout.ValueDuration())
 			}
 		}
 
 		if !plan.IdleKillTimeout.Equal(state.IdleKillTimeout) {
 			mustModify = true
 			if plan.IdleKillTimeout.IsNull() {
-				modifyOpts.IdleKillTimeout = utils.ToPtr("-1")
+				modifyOpts.IdleKillTimeout = utils.ToPtr(bastion.Duration(-1))
 			} else {
-				modifyOpts.IdleKillTimeout = utils.ToPtr(fmt.Sprintf("%d", plan.IdleKillTimeout.ValueInt64()))
+				modifyOpts.IdleKillTimeout = utils.ToPtr(plan.IdleKillTimeout.ValueDuration())
 			}
 		}
 
 		if !plan.GuestTtlLimit.Equal(state.GuestTtlLimit) {
 			mustModify = true
 			if plan.GuestTtlLimit.IsNull() {
-				modifyOpts.GuestTtlLimit = utils.ToPtr("0")
+				modifyOpts.GuestTtlLimit = utils.ToPtr(bastion.Duration(0))
 			} else {
-				modifyOpts.GuestTtlLimit = utils.ToPtr(fmt.Sprintf("%d", plan.GuestTtlLimit.ValueInt64()))
+				modifyOpts.GuestTtlLimit = utils.ToPtr(plan.GuestTtlLimit.ValueDuration())
 			}
 		}
 
@@ -608,3 +580,65 @@ func (r *GroupResource) Delete(ctx context.Context, req resource.DeleteRequest,
 func (r *GroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("group"), req, resp)
 }
+
+// Strategies to transfer the ownership of a group when its owner changes.
+const (
+	ownerChangeTransmit      = "transmit"
+	ownerChangeAddThenRemove = "add_then_remove"
+	ownerChangeAddOnly       = "add_only"
+)
+
+// changeOwner transfers the ownership of the group from the old owner to the new one using the given strategy.
+// When the old owner cannot be removed, the new owner is removed again so that the group is left unchanged.
+func (r *GroupResource) changeOwner(group, oldOwner, newOwner, strategy string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if strategy == ownerChangeTransmit {
+		// groupTransmitOwnership only transmits the ownership of the account the provider connects with
+		if oldOwner == r.client.Username() {
+			if err := r.client.GroupTransmitOwnership(group, newOwner); err != nil {
+				diags.AddError(
+					"Error Transmitting Group Ownership",
+					fmt.Sprintf("Could not transmit ownership of group %s to %s: %s", group, newOwner, err.Error()),
+				)
+			}
+			return diags
+		}
+
+		diags.AddWarning(
+			"Group Ownership Not Transmitted",
+			fmt.Sprintf("The ownership of group %s cannot be transmitted from %s, as the provider connects with %s. "+
+				"Falling back to %q.", group, oldOwner, r.client.Username(), ownerChangeAddThenRemove),
+		)
+	}
+
+	// Add the new owner
+	if err := r.client.GroupAddOwner(group, newOwner); err != nil {
+		diags.AddError(
+			"Error Adding New Group Owner",
+			fmt.Sprintf("Could not add %s as owner of group %s: %s", newOwner, group, err.Error()),
+		)
+		return diags
+	}
+
+	if strategy == ownerChangeAddOnly {
+		return diags
+	}
+
+	// Remove the old owner
+	if err := r.client.GroupRemoveOwner(group, oldOwner); err != nil {
+		detail := fmt.Sprintf("Could not remove %s as owner of group %s: %s", oldOwner, group, err.Error())
+
+		// Roll back the added owner
+		if rollbackErr := r.client.GroupRemoveOwner(group, newOwner); rollbackErr != nil {
+			detail += fmt.Sprintf("\n\nCould not remove the added owner %s again, the group has both owners: %s", newOwner, rollbackErr.Error())
+		}
+
+		diags.AddError(
+			"Error Removing Old Group Owner",
+			detail,
+		)
+	}
+
+	return diags
+}
@@ -0,0 +1,306 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = &GroupEgressKeyResource{}
+var _ resource.ResourceWithImportState = &GroupEgressKeyResource{}
+var _ resource.ResourceWithConfigure = &GroupEgressKeyResource{}
+
+// NewGroupEgressKeyResource is a helper function to simplify the provider implementation.
+func NewGroupEgressKeyResource() resource.Resource {
+	return &GroupEgressKeyResource{}
+}
+
+// GroupEgressKeyResource is the resource implementation.
+type GroupEgressKeyResource struct {
+	client *bastion.Client
+}
+
+// GroupEgressKeyResourceModel describes the resource data model.
+type GroupEgressKeyResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Group       types.String `tfsdk:"group"`
+	Algo        types.String `tfsdk:"algo"`
+	KeyID       types.String `tfsdk:"key_id"`
+	Fingerprint types.String `tfsdk:"fingerprint"`
+	Typecode    types.String `tfsdk:"typecode"`
+	Family      types.String `tfsdk:"family"`
+	Size        types.Int64  `tfsdk:"size"`
+	Line        types.String `tfsdk:"line"`
+}
+
+// Metadata returns the resource type name.
+func (r *GroupEgressKeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_egress_key"
+}
+
+// Schema defines the schema for the resource.
+func (r *GroupEgressKeyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an additional egress key of a Bastion group. " +
+			"Combined with `create_before_destroy`, it allows rotating the group keys: add a new key, deploy it to the servers, then remove the old one.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The resource identifier (group:fingerprint)",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"group": schema.StringAttribute{
+				MarkdownDescription: "The name of the Bastion group",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"algo": schema.StringAttribute{
+				MarkdownDescription: "The SSH key algorithm. Valid values: ed25519, rsa2048, rsa4096, rsa8192, ecdsa256, ecdsa384, ecdsa521. Defaults to ed25519.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("ed25519"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("ed25519", "rsa2048", "rsa4096", "rsa8192", "ecdsa256", "ecdsa384", "ecdsa521"),
+				},
+			},
+			"key_id": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the key on The Bastion",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"fingerprint": schema.StringAttribute{
+				MarkdownDescription: "The fingerprint of the key",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"typecode": schema.StringAttribute{
+				MarkdownDescription: "The SSH type code of the key (e.g. ssh-ed25519)",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"family": schema.StringAttribute{
+				MarkdownDescription: "The family of the key (e.g. ED25519, RSA)",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"size": schema.Int64Attribute{
+				MarkdownDescription: "The size of the key in bits",
+				Computed:            true,
+			},
+			"line": schema.StringAttribute{
+				MarkdownDescription: "The public key line, ready to be added to the authorized_keys file of the servers",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the bastion client to the resource.
+func (r *GroupEgressKeyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*bastion.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *bastion.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *GroupEgressKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan GroupEgressKeyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group, err := r.client.GroupInfo(plan.Group.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Bastion Group",
+			err.Error(),
+		)
+		return
+	}
+
+	existing := []string{}
+	for _, key := range group.Keys {
+		existing = append(existing, key.Fingerprint)
+	}
+
+	generated, err := r.client.GroupGenerateEgressKey(plan.Group.ValueString(), bastion.KeyAlgo(plan.Algo.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Generating Group Egress Key",
+			fmt.Sprintf("Could not generate egress key for group %s: %s", plan.Group.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	group, err = r.client.GroupInfo(plan.Group.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Bastion Group",
+			err.Error(),
+		)
+		return
+	}
+
+	// Find the generated key, or the only key which did not exist before
+	var key *bastion.Key
+	for _, k := range group.Keys {
+		if (generated.Fingerprint != "" && k.Fingerprint == generated.Fingerprint) ||
+			(generated.Fingerprint == "" && !slices.Contains(existing, k.Fingerprint)) {
+			key = &k
+			break
+		}
+	}
+	if key == nil {
+		resp.Diagnostics.AddError(
+			"Error Generating Group Egress Key",
+			fmt.Sprintf("The generated egress key of group %s could not be found", plan.Group.ValueString()),
+		)
+		return
+	}
+
+	setGroupEgressKeyModel(&plan, key)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *GroupEgressKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state GroupEgressKeyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group, err := r.client.GroupInfo(state.Group.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Bastion Group",
+			err.Error(),
+		)
+		return
+	}
+
+	var key *bastion.Key
+	for _, k := range group.Keys {
+		if k.Fingerprint == state.Fingerprint.ValueString() {
+			key = &k
+			break
+		}
+	}
+	if key == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	setGroupEgressKeyModel(&state, key)
+
+	// algo is not known after an import
+	if state.Algo.IsNull() {
+		state.Algo = types.StringValue(string(bastion.KeyAlgoFromFamily(key.Family, key.Size)))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *GroupEgressKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Since all attributes require replacement, this should never be called
+	resp.Diagnostics.AddError(
+		"Update Not Supported",
+		"Group egress keys cannot be updated. This is a bug in the provider.",
+	)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *GroupEgressKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state GroupEgressKeyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.GroupDelEgressKey(state.Group.ValueString(), state.KeyID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Group Egress Key",
+			fmt.Sprintf("Could not delete egress key %s of group %s: %s", state.Fingerprint.ValueString(), state.Group.ValueString(), err.Error()),
+		)
+		return
+	}
+}
+
+// ImportState imports the resource state.
+func (r *GroupEgressKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Fingerprints contain colons themselves, so only split on the first one
+	group, fingerprint, ok := strings.Cut(req.ID, ":")
+	if !ok || group == "" || fingerprint == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID in the format 'group:fingerprint', got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group"), group)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("fingerprint"), fingerprint)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
+
+// setGroupEgressKeyModel maps a group key to the resource model.
+func setGroupEgressKeyModel(model *GroupEgressKeyResourceModel, key *bastion.Key) {
+	model.ID = types.StringValue(fmt.Sprintf("%s:%s", model.Group.ValueString(), key.Fingerprint))
+	model.KeyID = types.StringValue(key.ID)
+	model.Fingerprint = types.StringValue(key.Fingerprint)
+	model.Typecode = types.StringValue(key.Typecode)
+	model.Family = types.StringValue(key.Family)
+	model.Size = types.Int64Value(int64(key.Size))
+	model.Line = types.StringValue(key.Line)
+}
@@ -0,0 +1,104 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/adfinis/terraform-provider-bastion/internal/provider/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccGroupEgressKeyResource(t *testing.T) {
+	err := testutils.CreateGroup("testgroupkey1", "bastionadmin", bastion.ED25519)
+	if err != nil {
+		t.Errorf("Unable to create test group: %s", err)
+	}
+
+	t.Cleanup(func() {
+		err := testutils.DeleteGroup("testgroupkey1")
+		if err != nil {
+			t.Errorf("Unable to delete test group: %s", err)
+		}
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccGroupEgressKeyResourceConfig("testgroupkey1", "ed25519"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"bastion_group_egress_key.test",
+						tfjsonpath.New("family"),
+						knownvalue.StringExact("ED25519"),
+					),
+					statecheck.ExpectKnownValue(
+						"bastion_group_egress_key.test",
+						tfjsonpath.New("typecode"),
+						knownvalue.StringExact("ssh-ed25519"),
+					),
+					statecheck.ExpectKnownValue(
+						"bastion_group_egress_key.test",
+						tfjsonpath.New("fingerprint"),
+						knownvalue.NotNull(),
+					),
+					statecheck.ExpectKnownValue(
+						"bastion_group_egress_key.test",
+						tfjsonpath.New("line"),
+						knownvalue.NotNull(),
+					),
+				},
+			},
+			// ImportState testing
+			{
+				ResourceName:      "bastion_group_egress_key.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return s.RootModule().Resources["bastion_group_egress_key.test"].Primary.ID, nil
+				},
+			},
+			// Changing the algorithm replaces the key
+			{
+				Config: testAccGroupEgressKeyResourceConfig("testgroupkey1", "ecdsa256"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("bastion_group_egress_key.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"bastion_group_egress_key.test",
+						tfjsonpath.New("family"),
+						knownvalue.StringExact("ECDSA"),
+					),
+					statecheck.ExpectKnownValue(
+						"bastion_group_egress_key.test",
+						tfjsonpath.New("size"),
+						knownvalue.Int64Exact(256),
+					),
+				},
+			},
+		},
+	})
+}
+
+func testAccGroupEgressKeyResourceConfig(group, algo string) string {
+	return providerConfig + fmt.Sprintf(`
+resource "bastion_group_egress_key" "test" {
+  group = %[1]q
+  algo  = %[2]q
+}
+`, group, algo)
+}
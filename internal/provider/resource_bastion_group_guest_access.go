@@ -6,10 +6,11 @@ package provider
 import (
 	"context"
 	"fmt"
-	"strconv"
-	"strings"
+	"slices"
+	"time"
 
 	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/adfinis/terraform-provider-bastion/internal/provider/customtypes"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -24,32 +25,56 @@ import (
 var _ resource.Resource = &GroupGuestAccessResource{}
 var _ resource.ResourceWithConfigure = &GroupGuestAccessResource{}
 var _ resource.ResourceWithImportState = &GroupGuestAccessResource{}
+var _ resource.ResourceWithModifyPlan = &GroupGuestAccessResource{}
 
 // NewGroupGuestAccessResource is a helper function to simplify the provider implementation.
-func NewGroupGuestAccessResource() resource.Resource {
-	return &GroupGuestAccessResource{}
+// The created resources resolve hostnames with the given resolver.
+func NewGroupGuestAccessResource(resolver HostnameResolver) func() resource.Resource {
+	return func() resource.Resource {
+		return &GroupGuestAccessResource{resolver: resolver}
+	}
 }
 
 // GroupGuestAccessResource is the resource implementation.
 type GroupGuestAccessResource struct {
-	client *bastion.Client
+	client   *bastion.Client
+	resolver HostnameResolver
 }
 
 // GroupGuestAccessResourceModel describes the resource data model.
 type GroupGuestAccessResourceModel struct {
-	ID         types.String `tfsdk:"id"`
-	Group      types.String `tfsdk:"group"`
-	Account    types.String `tfsdk:"account"`
-	IP         types.String `tfsdk:"ip"`
-	Port       types.String `tfsdk:"port"`
-	User       types.String `tfsdk:"user"`
-	Protocol   types.String `tfsdk:"protocol"`
-	ProxyIP    types.String `tfsdk:"proxy_ip"`
-	ProxyPort  types.String `tfsdk:"proxy_port"`
-	ProxyUser  types.String `tfsdk:"proxy_user"`
-	Comment    types.String `tfsdk:"comment"`
-	TTL        types.Int64  `tfsdk:"ttl"`
-	RemotePort types.Int64  `tfsdk:"remote_port"`
+	ID          types.String          `tfsdk:"id"`
+	Group       types.String          `tfsdk:"group"`
+	Account     types.String          `tfsdk:"account"`
+	IP          customtypes.IPAddress `tfsdk:"ip"`
+	Hostname    types.String          `tfsdk:"hostname"`
+	ResolvedIPs types.List            `tfsdk:"resolved_ips"`
+	Port        types.String          `tfsdk:"port"`
+	User        types.String          `tfsdk:"user"`
+	Protocol    types.String          `tfsdk:"protocol"`
+	ProxyIP     customtypes.IPAddress `tfsdk:"proxy_ip"`
+	ProxyPort   types.String          `tfsdk:"proxy_port"`
+	ProxyUser   types.String          `tfsdk:"proxy_user"`
+	Comment     types.String          `tfsdk:"comment"`
+	TTL         customtypes.Duration  `tfsdk:"ttl"`
+	RemotePort  types.Int64           `tfsdk:"remote_port"`
+	ExpiresAt   types.String          `tfsdk:"expires_at"`
+	OnExpiry    types.String          `tfsdk:"on_expiry"`
+}
+
+// acl returns the attributes the guest access shares with the ACL entries of The Bastion.
+func (m *GroupGuestAccessResourceModel) acl() accessACL {
+	return accessACL{
+		IP:         &m.IP,
+		Port:       &m.Port,
+		User:       &m.User,
+		Protocol:   &m.Protocol,
+		ProxyIP:    &m.ProxyIP,
+		ProxyPort:  &m.ProxyPort,
+		ProxyUser:  &m.ProxyUser,
+		RemotePort: &m.RemotePort,
+		Comment:    &m.Comment,
+	}
 }
 
 // Metadata returns the resource type name.
@@ -86,12 +111,16 @@ Some features like proxyjump accesses and port forwardings are only supported wh
 				},
 			},
 			"ip": schema.StringAttribute{
-				MarkdownDescription: "IP or subnet of the server access target (hostname does not work)",
-				Required:            true,
+				CustomType:          customtypes.IPAddressType{},
+				MarkdownDescription: "IP or subnet of the server access target. Exactly one of `ip` and `hostname` must be set.",
+				Optional:            true,
 				PlanModifiers: []planmodifier.String{
+					customtypes.IPAddressPlanModifier(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"hostname":     hostnameAttribute(),
+			"resolved_ips": resolvedIPsAttribute(),
 			"port": schema.StringAttribute{
 				MarkdownDescription: "Port of the access target, use '*' to allow ssh access to all ports",
 				Required:            true,
@@ -121,6 +150,7 @@ Some features like proxyjump accesses and port forwardings are only supported wh
 				},
 			},
 			"proxy_ip": schema.StringAttribute{
+				CustomType:          customtypes.IPAddressType{},
 				MarkdownDescription: "IP address of the proxy server",
 				Optional:            true,
 				Validators: []validator.String{
@@ -128,6 +158,7 @@ Some features like proxyjump accesses and port forwardings are only supported wh
 					stringvalidator.AlsoRequires(path.MatchRoot("proxy_user")),
 				},
 				PlanModifiers: []planmodifier.String{
+					customtypes.IPAddressPlanModifier(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -152,11 +183,14 @@ Some features like proxyjump accesses and port forwardings are only supported wh
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
-			"ttl": schema.Int64Attribute{
-				MarkdownDescription: "Time to live for the guest access in seconds",
+			"ttl": schema.StringAttribute{
+				CustomType:          customtypes.DurationType{},
+				MarkdownDescription: "Time to live for the guest access, as a number of seconds or a duration like `90m` or `7d`",
 				Optional:            true,
-				PlanModifiers: []planmodifier.Int64{
-					int64planmodifier.RequiresReplace(),
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					customtypes.DurationPlanModifier(),
+					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"remote_port": schema.Int64Attribute{
@@ -167,6 +201,8 @@ Some features like proxyjump accesses and port forwardings are only supported wh
 					int64planmodifier.RequiresReplace(),
 				},
 			},
+			"expires_at": expiresAtAttribute("guest access"),
+			"on_expiry":  onExpiryAttribute(),
 		},
 	}
 }
@@ -207,54 +243,64 @@ func (r *GroupGuestAccessResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
-	// Build options
-	options := &bastion.GroupAddGuestAccessOptions{}
-
-	if !plan.Comment.IsNull() {
-		options.Comment = plan.Comment.ValueString()
-	}
-
-	if !plan.TTL.IsNull() {
-		options.TTL = strconv.FormatInt(plan.TTL.ValueInt64(), 10)
-	}
-
-	if !plan.Protocol.IsNull() {
-		options.Protocol = plan.Protocol.ValueString()
-	}
-
-	// Handle proxy options
-	if !plan.ProxyIP.IsNull() || !plan.ProxyPort.IsNull() || !plan.ProxyUser.IsNull() {
-		options.ProxyOptions = &bastion.ProxyOptions{
-			ProxyHost: plan.ProxyIP.ValueString(),
-			ProxyPort: plan.ProxyPort.ValueString(),
-			ProxyUser: plan.ProxyUser.ValueString(),
-		}
+	options, err := groupAddGuestAccessOptions(&plan)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("expires_at"),
+			"Error Creating Group Guest Access",
+			err.Error(),
+		)
+		return
 	}
 
-	if !plan.RemotePort.IsNull() {
-		remotePort := int(plan.RemotePort.ValueInt64())
-		options.RemotePort = &remotePort
+	// The hostname is resolved now when it was unknown at plan time
+	plan.ResolvedIPs, err = resolveUnknownIPs(ctx, r.resolver, plan.Hostname, plan.ResolvedIPs)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("hostname"),
+			"Error Resolving Hostname",
+			err.Error(),
+		)
+		return
 	}
 
-	// Add the guest access
-	err := r.client.GroupAddGuestAccess(
-		plan.Group.ValueString(),
-		plan.Account.ValueString(),
-		plan.IP.ValueString(),
-		plan.Port.ValueString(),
-		plan.User.ValueString(),
-		options,
-	)
+	targets, err := accessTargets(ctx, plan.IP.ValueCanonical(), plan.ResolvedIPs)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error Adding Group Guest Access",
-			fmt.Sprintf("Could not add guest access for account %s to group %s: %s", plan.Account.ValueString(), plan.Group.ValueString(), err.Error()),
+			"Error Creating Group Guest Access",
+			err.Error(),
 		)
 		return
 	}
 
+	// Add the guest access, once per resolved address of the hostname
+	for i, ip := range targets {
+		err := r.client.GroupAddGuestAccess(
+			plan.Group.ValueString(),
+			plan.Account.ValueString(),
+			ip,
+			plan.Port.ValueString(),
+			plan.User.ValueString(),
+			options,
+		)
+		if err != nil {
+			// Roll back the accesses already added, the resource is not created
+			for _, addedIP := range targets[:i] {
+				_ = r.delGuestAccess(&plan, addedIP)
+			}
+			resp.Diagnostics.AddError(
+				"Error Adding Group Guest Access",
+				fmt.Sprintf("Could not add guest access to %s for account %s to group %s: %s", ip, plan.Account.ValueString(), plan.Group.ValueString(), err.Error()),
+			)
+			return
+		}
+	}
+
 	// Generate ID
 	plan.ID = types.StringValue(generateGuestAccessID(&plan))
+	if plan.ExpiresAt.IsUnknown() {
+		plan.ExpiresAt = expiresAtFromTTL(plan.TTL)
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
@@ -278,325 +324,331 @@ func (r *GroupGuestAccessResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
-	// Find matching guest access
+	targets, err := accessTargets(ctx, state.IP.ValueCanonical(), state.ResolvedIPs)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Group Guest Accesses",
+			err.Error(),
+		)
+		return
+	}
+
+	// Find matching guest accesses, one per resolved address of the hostname
 	var found *bastion.GroupGuestAccess
-	for _, access := range accesses {
-		if matchesGuestAccess(&state, access) {
-			found = access
-			break
+	var foundIPs []string
+	for _, ip := range targets {
+		target := state
+		target.IP = customtypes.NewIPAddressValue(ip)
+		for _, access := range accesses {
+			if matchesACL(target.acl(), (*bastion.ACL)(access)) {
+				if found == nil {
+					found = access
+				}
+				foundIPs = append(foundIPs, ip)
+				break
+			}
 		}
 	}
 
 	if found == nil {
+		if keepExpiredAccess(state.OnExpiry, state.ExpiresAt) {
+			// The access expired, keep it in the state so that it is not added again
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+			return
+		}
 		resp.State.RemoveResource(ctx)
 		return
 	}
 
 	// Update state from API response
-	state.IP = types.StringValue(found.IP)
-	if found.Port != nil {
-		state.Port = types.StringValue(found.Port.ValueString())
+	if state.Hostname.IsNull() {
+		state.IP = customtypes.NewIPAddressValue(found.IP)
 	} else {
-		state.Port = types.StringValue("*")
+		// Addresses removed outside of Terraform show up as a diff against the resolution
+		resolvedIPs, diags := types.ListValueFrom(ctx, types.StringType, foundIPs)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		state.ResolvedIPs = resolvedIPs
 	}
+	flattenACL(state.acl(), (*bastion.ACL)(found))
 
-	// Handle protocol
-	if found.User != nil && strings.HasPrefix(*found.User, "!") {
-		// This is a protocol access
-		state.Protocol = types.StringValue(strings.TrimPrefix(*found.User, "!"))
-		state.User = types.StringNull()
-	} else if found.User != nil {
-		state.User = types.StringValue(*found.User)
-		state.Protocol = types.StringNull()
-	} else {
-		state.User = types.StringValue("*")
-		state.Protocol = types.StringNull()
+	state.ExpiresAt = refreshExpiresAt(state.ExpiresAt, found.Expiry)
+
+	// on_expiry is not known after an import
+	if state.OnExpiry.IsNull() {
+		state.OnExpiry = types.StringValue(onExpiryRecreate)
 	}
 
-	if found.ProxyIP != nil {
-		state.ProxyIP = types.StringValue(*found.ProxyIP)
-	} else {
-		state.ProxyIP = types.StringNull()
+	// Update ID
+	state.ID = types.StringValue(generateGuestAccessID(&state))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// ModifyPlan resolves the hostname, validates the expiry of the guest access and rejects plans keeping an expired access
+// when on_expiry is set to "error".
+func (r *GroupGuestAccessResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planResolvedIPs(ctx, r.resolver, req, resp)
+	checkExpiresAtPlan(ctx, req, resp)
+	checkExpiredAccessPlan(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if found.ProxyPort != nil {
-		state.ProxyPort = types.StringValue(found.ProxyPort.ValueString())
-	} else if !state.ProxyPort.IsNull() && state.ProxyPort.ValueString() == "*" {
-		state.ProxyPort = types.StringValue("*")
-	} else {
-		state.ProxyPort = types.StringNull()
+
+	r.checkGuestTTLLimit(ctx, req, resp)
+}
+
+// checkGuestTTLLimit warns when the expiry of a new guest access goes beyond the guest TTL limit of the group.
+func (r *GroupGuestAccessResource) checkGuestTTLLimit(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Only new guest accesses are checked, and the client is not configured during validation
+	if req.Plan.Raw.IsNull() || (!req.State.Raw.IsNull() && len(resp.RequiresReplace) == 0) || r.client == nil {
+		return
 	}
-	if found.ProxyUser != nil {
-		state.ProxyUser = types.StringValue(*found.ProxyUser)
-	} else {
-		state.ProxyUser = types.StringNull()
+
+	var plan GroupGuestAccessResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Group.IsUnknown() || plan.ExpiresAt.IsNull() || plan.ExpiresAt.IsUnknown() {
+		return
 	}
 
-	if found.RemotePort != nil {
-		state.RemotePort = types.Int64Value(int64(found.RemotePort.ValueInt()))
-	} else {
-		state.RemotePort = types.Int64Null()
+	expiresAt, err := time.Parse(time.RFC3339, plan.ExpiresAt.ValueString())
+	if err != nil {
+		return
 	}
 
-	// for some reason comment becomes userComment
-	if found.UserComment != nil {
-		state.Comment = types.StringValue(*found.UserComment)
-	} else {
-		state.Comment = types.StringNull()
+	// The group may not exist yet, the limit is checked by The Bastion in that case
+	group, err := r.client.GroupInfo(plan.Group.ValueString())
+	if err != nil {
+		return
 	}
 
-	// Update ID
-	state.ID = types.StringValue(generateGuestAccessID(&state))
+	if group.GuestTtlLimit == nil || *group.GuestTtlLimit <= 0 {
+		return
+	}
+	limit := *group.GuestTtlLimit
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if expiresAt.After(time.Now().Add(time.Duration(limit.Seconds()) * time.Second)) {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("expires_at"),
+			"Expiry Beyond Guest TTL Limit",
+			fmt.Sprintf("The expiry timestamp %s goes beyond the guest TTL limit of %s of group %s. The Bastion refuses guest accesses exceeding this limit.",
+				plan.ExpiresAt.ValueString(), limit, plan.Group.ValueString()),
+		)
+	}
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *GroupGuestAccessResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError(
-		"Update Not Supported",
-		"Group guest access updates are not supported. All changes require resource replacement.",
-	)
-}
-
-// Delete deletes the resource and removes the Terraform state on success.
-func (r *GroupGuestAccessResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state GroupGuestAccessResourceModel
+	var plan, state GroupGuestAccessResourceModel
 
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	proxyOpts := buildProxyOptionsFromState(&state)
-	var remotePort *int64
-	if !state.RemotePort.IsNull() {
-		rp := state.RemotePort.ValueInt64()
-		remotePort = &rp
-	}
+	// All other attributes require replacement, only on_expiry and the resolved addresses of the hostname can change
+	plan.ID = state.ID
+	plan.ExpiresAt = state.ExpiresAt
 
-	err := r.client.GroupDelGuestAccess(
-		state.Group.ValueString(),
-		state.Account.ValueString(),
-		state.IP.ValueString(),
-		state.Port.ValueString(),
-		state.User.ValueString(),
-		state.Protocol.ValueString(),
-		proxyOpts,
-		remotePort,
-	)
+	priorTargets, err := accessTargets(ctx, state.IP.ValueCanonical(), state.ResolvedIPs)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error Deleting Group Guest Access",
-			fmt.Sprintf("Could not delete guest access: %s", err.Error()),
+			"Error Updating Group Guest Access",
+			err.Error(),
 		)
 		return
 	}
-}
 
-// ImportState imports an existing resource by ID.
-func (r *GroupGuestAccessResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	importID := req.ID
-	parts := parseImportID(importID)
+	// The hostname is resolved now when it was unknown at plan time
+	plan.ResolvedIPs, err = resolveUnknownIPs(ctx, r.resolver, plan.Hostname, plan.ResolvedIPs)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("hostname"),
+			"Error Resolving Hostname",
+			err.Error(),
+		)
+		return
+	}
 
-	// Minimum is group:account:ip:port:user (5 parts)
-	if len(parts) < 5 || len(parts) > 10 {
+	targets, err := accessTargets(ctx, plan.IP.ValueCanonical(), plan.ResolvedIPs)
+	if err != nil {
 		resp.Diagnostics.AddError(
-			"Invalid Import ID",
-			fmt.Sprintf("Expected import ID in the format 'group:account:ip:port:user', 'group:account:ip:port:user:protocol', 'group:account:ip:port:user:protocol:remote_port', 'group:account:ip:port:user:proxy_ip:proxy_port:proxy_user', 'group:account:ip:port:user:protocol:proxy_ip:proxy_port:proxy_user', or 'group:account:ip:port:user:protocol:remote_port:proxy_ip:proxy_port:proxy_user', got: %s", importID),
+			"Error Updating Group Guest Access",
+			err.Error(),
 		)
 		return
 	}
+	added, removed := diffTargets(priorTargets, targets)
+	if accessExpired(state.ExpiresAt) {
+		// The Bastion already removed the expired accesses, they are not granted again
+		added = nil
+	}
 
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group"), parts[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("account"), parts[1])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("ip"), parts[2])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("port"), parts[3])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user"), parts[4])...)
+	// Added addresses get the same expiry as the existing accesses
+	options, err := groupAddGuestAccessOptions(&plan)
+	if len(added) > 0 && err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("expires_at"),
+			"Error Updating Group Guest Access",
+			err.Error(),
+		)
+		return
+	}
 
-	if len(parts) == 6 {
-		// group:account:ip:port:user:protocol
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("protocol"), parts[5])...)
-	} else if len(parts) == 7 {
-		// group:account:ip:port:user:protocol:remote_port (when protocol is "portforward")
-		protocol := parts[5]
-		if protocol != "portforward" {
+	granted := slices.Clone(priorTargets)
+	for _, ip := range added {
+		if err := r.client.GroupAddGuestAccess(
+			plan.Group.ValueString(),
+			plan.Account.ValueString(),
+			ip,
+			plan.Port.ValueString(),
+			plan.User.ValueString(),
+			options,
+		); err != nil {
 			resp.Diagnostics.AddError(
-				"Invalid Import ID",
-				fmt.Sprintf("When specifying remote_port, protocol must be 'portforward', got: %s", protocol),
+				"Error Adding Group Guest Access",
+				fmt.Sprintf("Could not add guest access to %s for account %s to group %s: %s", ip, plan.Account.ValueString(), plan.Group.ValueString(), err.Error()),
 			)
+			r.setGrantedTargets(ctx, resp, &state, granted)
 			return
 		}
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("protocol"), protocol)...)
-		remotePort, err := strconv.ParseInt(parts[6], 10, 64)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Invalid Import ID",
-				fmt.Sprintf("Invalid remote_port value '%s': %s", parts[6], err.Error()),
-			)
-			return
-		}
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("remote_port"), remotePort)...)
-	} else if len(parts) == 8 {
-		// group:account:ip:port:user:proxy_ip:proxy_port:proxy_user
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("proxy_ip"), parts[5])...)
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("proxy_port"), parts[6])...)
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("proxy_user"), parts[7])...)
-	} else if len(parts) == 9 {
-		// group:account:ip:port:user:protocol:proxy_ip:proxy_port:proxy_user
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("protocol"), parts[5])...)
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("proxy_ip"), parts[6])...)
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("proxy_port"), parts[7])...)
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("proxy_user"), parts[8])...)
-	} else if len(parts) == 10 {
-		// group:account:ip:port:user:protocol:remote_port:proxy_ip:proxy_port:proxy_user
-		protocol := parts[5]
-		if protocol != "portforward" {
+		granted = append(granted, ip)
+	}
+
+	for _, ip := range removed {
+		if err := r.delGuestAccess(&state, ip); err != nil && !accessExpired(state.ExpiresAt) {
 			resp.Diagnostics.AddError(
-				"Invalid Import ID",
-				fmt.Sprintf("When specifying remote_port with proxy, protocol must be 'portforward', got: %s", protocol),
+				"Error Deleting Group Guest Access",
+				fmt.Sprintf("Could not delete guest access to %s: %s", ip, err.Error()),
 			)
+			r.setGrantedTargets(ctx, resp, &state, granted)
 			return
 		}
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("protocol"), protocol)...)
-		remotePort, err := strconv.ParseInt(parts[6], 10, 64)
-		if err != nil {
+		granted = slices.DeleteFunc(granted, func(g string) bool { return g == ip })
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// setGrantedTargets saves the prior state with the addresses the guest access is granted to after a partial update.
+func (r *GroupGuestAccessResource) setGrantedTargets(ctx context.Context, resp *resource.UpdateResponse, state *GroupGuestAccessResourceModel, granted []string) {
+	if state.Hostname.IsNull() {
+		return
+	}
+
+	resolvedIPs, diags := types.ListValueFrom(ctx, types.StringType, granted)
+	resp.Diagnostics.Append(diags...)
+	state.ResolvedIPs = resolvedIPs
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *GroupGuestAccessResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state GroupGuestAccessResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	targets, err := accessTargets(ctx, state.IP.ValueCanonical(), state.ResolvedIPs)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Group Guest Access",
+			err.Error(),
+		)
+		return
+	}
+
+	for _, ip := range targets {
+		err := r.delGuestAccess(&state, ip)
+		if err != nil && !accessExpired(state.ExpiresAt) {
 			resp.Diagnostics.AddError(
-				"Invalid Import ID",
-				fmt.Sprintf("Invalid remote_port value '%s': %s", parts[6], err.Error()),
+				"Error Deleting Group Guest Access",
+				fmt.Sprintf("Could not delete guest access: %s", err.Error()),
 			)
 			return
 		}
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("remote_port"), remotePort)...)
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("proxy_ip"), parts[7])...)
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("proxy_port"), parts[8])...)
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("proxy_user"), parts[9])...)
 	}
-
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), importID)...)
 }
 
-// generateGuestAccessID generates a unique ID for a guest access.
-func generateGuestAccessID(model *GroupGuestAccessResourceModel) string {
-	ip := formatIPForID(model.IP.ValueString())
-	proxyIP := ""
-	if !model.ProxyIP.IsNull() {
-		proxyIP = formatIPForID(model.ProxyIP.ValueString())
+// delGuestAccess removes the guest access to the given address.
+func (r *GroupGuestAccessResource) delGuestAccess(model *GroupGuestAccessResourceModel, ip string) error {
+	proxyOpts := buildProxyOptionsFromState(model)
+	var remotePort *int64
+	if !model.RemotePort.IsNull() {
+		rp := model.RemotePort.ValueInt64()
+		remotePort = &rp
 	}
 
-	id := fmt.Sprintf("%s:%s:%s:%s:%s",
+	return r.client.GroupDelGuestAccess(
 		model.Group.ValueString(),
 		model.Account.ValueString(),
 		ip,
 		model.Port.ValueString(),
 		model.User.ValueString(),
+		model.Protocol.ValueString(),
+		proxyOpts,
+		remotePort,
 	)
+}
 
-	// Add protocol if present
-	if !model.Protocol.IsNull() {
-		id = fmt.Sprintf("%s:%s", id, model.Protocol.ValueString())
-	}
-
-	// Add remote_port if present (only valid with portforward protocol)
-	if !model.RemotePort.IsNull() {
-		id = fmt.Sprintf("%s:%d", id, model.RemotePort.ValueInt64())
-	}
-
-	if !model.ProxyIP.IsNull() {
-		id = fmt.Sprintf("%s:%s:%s:%s",
-			id,
-			proxyIP,
-			model.ProxyPort.ValueString(),
-			model.ProxyUser.ValueString(),
-		)
-	}
-
-	return id
+// ImportState imports an existing resource by ID.
+func (r *GroupGuestAccessResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importAccessACL(ctx, req.ID, []string{"group", "account"}, resp)
 }
 
-// matchesGuestAccess checks if a guest access matches the state.
-func matchesGuestAccess(state *GroupGuestAccessResourceModel, access *bastion.GroupGuestAccess) bool {
-	if state.IP.ValueString() != access.IP {
-		return false
+// generateGuestAccessID generates a unique ID for a guest access, using the hostname instead of the IP when set.
+func generateGuestAccessID(model *GroupGuestAccessResourceModel) string {
+	target := formatIPForID(model.IP.ValueString())
+	if !model.Hostname.IsNull() {
+		target = model.Hostname.ValueString()
 	}
+	return accessACLID([]string{model.Group.ValueString(), model.Account.ValueString()}, target, model.acl())
+}
 
-	// Check port (API returns null for "*")
-	statePort := state.Port.ValueString()
-	accessPort := "*"
-	if access.Port != nil {
-		accessPort = access.Port.ValueString()
-	}
-	if statePort != accessPort {
-		return false
-	}
+// groupAddGuestAccessOptions builds the options to add the guest access of the model.
+func groupAddGuestAccessOptions(model *GroupGuestAccessResourceModel) (*bastion.GroupAddGuestAccessOptions, error) {
+	options := &bastion.GroupAddGuestAccessOptions{}
 
-	// Check user (API returns null for "*")
-	// For protocol accesses, API returns username as "!protocol"
-	stateUser := state.User.ValueString()
-	stateProtocol := ""
-	if !state.Protocol.IsNull() {
-		stateProtocol = state.Protocol.ValueString()
+	if !model.Comment.IsNull() {
+		options.Comment = model.Comment.ValueString()
 	}
 
-	accessUser := "*"
-	if access.User != nil {
-		accessUser = *access.User
+	if !model.TTL.IsNull() {
+		options.TTL = model.TTL.ValueDuration()
 	}
 
-	// If state has a protocol, match against "!protocol" in access user
-	if stateProtocol != "" {
-		expectedUser := "!" + stateProtocol
-		if accessUser != expectedUser {
-			return false
-		}
-	} else {
-		if stateUser != accessUser {
-			return false
+	if !model.ExpiresAt.IsNull() && !model.ExpiresAt.IsUnknown() {
+		ttl, err := ttlFromExpiresAt(model.ExpiresAt)
+		if err != nil {
+			return nil, err
 		}
+		options.TTL = ttl
 	}
 
-	// Check remote_port (for portforward protocol)
-	stateHasRemotePort := !state.RemotePort.IsNull()
-	accessHasRemotePort := access.RemotePort != nil
-
-	if stateHasRemotePort != accessHasRemotePort {
-		return false
+	if !model.Protocol.IsNull() {
+		options.Protocol = model.Protocol.ValueString()
 	}
 
-	if stateHasRemotePort {
-		if state.RemotePort.ValueInt64() != int64(access.RemotePort.ValueInt()) {
-			return false
+	// Handle proxy options
+	if !model.ProxyIP.IsNull() || !model.ProxyPort.IsNull() || !model.ProxyUser.IsNull() {
+		options.ProxyOptions = &bastion.ProxyOptions{
+			ProxyHost: model.ProxyIP.ValueCanonical(),
+			ProxyPort: model.ProxyPort.ValueString(),
+			ProxyUser: model.ProxyUser.ValueString(),
 		}
 	}
 
-	// Check proxy settings
-	stateHasProxy := !state.ProxyIP.IsNull()
-	accessHasProxy := access.ProxyIP != nil
-
-	if stateHasProxy != accessHasProxy {
-		return false
-	}
-
-	if stateHasProxy {
-		if state.ProxyIP.ValueString() != *access.ProxyIP {
-			return false
-		}
-
-		// Check proxy port (API returns null for "*")
-		stateProxyPort := state.ProxyPort.ValueString()
-		accessProxyPort := "*"
-		if access.ProxyPort != nil {
-			accessProxyPort = access.ProxyPort.ValueString()
-		}
-		if stateProxyPort != accessProxyPort {
-			return false
-		}
-
-		if state.ProxyUser.ValueString() != *access.ProxyUser {
-			return false
-		}
+	if !model.RemotePort.IsNull() {
+		remotePort := int(model.RemotePort.ValueInt64())
+		options.RemotePort = &remotePort
 	}
 
-	return true
+	return options, nil
 }
 
 // buildProxyOptionsFromState builds ProxyOptions from state values.
@@ -605,7 +657,7 @@ func buildProxyOptionsFromState(state *GroupGuestAccessResourceModel) *bastion.P
 		return nil
 	}
 	return &bastion.ProxyOptions{
-		ProxyHost: state.ProxyIP.ValueString(),
+		ProxyHost: state.ProxyIP.ValueCanonical(),
 		ProxyPort: state.ProxyPort.ValueString(),
 		ProxyUser: state.ProxyUser.ValueString(),
 	}
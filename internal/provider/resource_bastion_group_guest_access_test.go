@@ -5,12 +5,15 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/adfinis/terraform-provider-bastion/bastion"
 	"github.com/adfinis/terraform-provider-bastion/internal/provider/testutils"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 )
@@ -85,6 +88,16 @@ func TestAccGroupGuestAccessResource(t *testing.T) {
 						tfjsonpath.New("id"),
 						knownvalue.StringExact(fmt.Sprintf("%s:%s:192.168.1.100:22:root", groupName, accountName)),
 					),
+					statecheck.ExpectKnownValue(
+						"bastion_group_guest_access.test",
+						tfjsonpath.New("expires_at"),
+						knownvalue.Null(),
+					),
+					statecheck.ExpectKnownValue(
+						"bastion_group_guest_access.test",
+						tfjsonpath.New("on_expiry"),
+						knownvalue.StringExact("recreate"),
+					),
 				},
 			},
 			// ImportState testing
@@ -388,6 +401,154 @@ func TestAccGroupGuestAccessResource_Multiple(t *testing.T) {
 	})
 }
 
+func TestAccGroupGuestAccessResource_ExpiresAt(t *testing.T) {
+	groupName := "testgrpguest7"
+	accountName := "testguestaccount7"
+	expiresAt := time.Now().Add(2 * time.Hour).UTC().Truncate(time.Second).Format(time.RFC3339)
+	past := time.Now().Add(-time.Hour).UTC().Truncate(time.Second).Format(time.RFC3339)
+
+	err := testutils.CreateGroup(groupName, "bastionadmin", bastion.ED25519)
+	if err != nil {
+		t.Errorf("Unable to create test group: %s", err)
+	}
+	err = testutils.CreateAccount(accountName)
+	if err != nil {
+		t.Errorf("Unable to create test account: %s", err)
+	}
+	err = testutils.CreateGroupServerAccess(groupName, "192.168.1.100", "22", "root")
+	if err != nil {
+		t.Errorf("Unable to create test server access: %s", err)
+	}
+
+	t.Cleanup(func() {
+		err := testutils.DeleteGroupServerAccess(groupName, "192.168.1.100", "22", "root")
+		if err != nil {
+			t.Errorf("Unable to delete test server access: %s", err)
+		}
+		err = testutils.DeleteAccount(accountName)
+		if err != nil {
+			t.Errorf("Unable to delete test account: %s", err)
+		}
+		err = testutils.DeleteGroup(groupName)
+		if err != nil {
+			t.Errorf("Unable to delete test group: %s", err)
+		}
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Timestamps in the past are rejected at plan time
+			{
+				Config:      testAccGroupGuestAccessResourceConfigExpiresAt(groupName, accountName, past),
+				ExpectError: regexp.MustCompile("lies in the past"),
+			},
+			{
+				Config: testAccGroupGuestAccessResourceConfigExpiresAt(groupName, accountName, expiresAt),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"bastion_group_guest_access.test",
+						tfjsonpath.New("expires_at"),
+						knownvalue.StringExact(expiresAt),
+					),
+					statecheck.ExpectKnownValue(
+						"bastion_group_guest_access.test",
+						tfjsonpath.New("ttl"),
+						knownvalue.Null(),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccGroupGuestAccessResource_Hostname(t *testing.T) {
+	groupName := "testgrpguest8"
+	accountName := "testguestaccount8"
+	resolver := StaticResolver{
+		"watchtower.example.com": {"192.168.1.100", "192.168.1.101"},
+	}
+
+	err := testutils.CreateGroup(groupName, "bastionadmin", bastion.ED25519)
+	if err != nil {
+		t.Errorf("Unable to create test group: %s", err)
+	}
+	err = testutils.CreateAccount(accountName)
+	if err != nil {
+		t.Errorf("Unable to create test account: %s", err)
+	}
+	for _, ip := range []string{"192.168.1.100", "192.168.1.101"} {
+		err = testutils.CreateGroupServerAccess(groupName, ip, "22", "root")
+		if err != nil {
+			t.Errorf("Unable to create test server access: %s", err)
+		}
+	}
+
+	t.Cleanup(func() {
+		for _, ip := range []string{"192.168.1.100", "192.168.1.101"} {
+			err := testutils.DeleteGroupServerAccess(groupName, ip, "22", "root")
+			if err != nil {
+				t.Errorf("Unable to delete test server access: %s", err)
+			}
+		}
+		err := testutils.DeleteAccount(accountName)
+		if err != nil {
+			t.Errorf("Unable to delete test account: %s", err)
+		}
+		err = testutils.DeleteGroup(groupName)
+		if err != nil {
+			t.Errorf("Unable to delete test group: %s", err)
+		}
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithResolver(resolver),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGroupGuestAccessResourceConfigHostname(groupName, accountName, "watchtower.example.com"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"bastion_group_guest_access.test",
+						tfjsonpath.New("resolved_ips"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.StringExact("192.168.1.100"),
+							knownvalue.StringExact("192.168.1.101"),
+						}),
+					),
+					statecheck.ExpectKnownValue(
+						"bastion_group_guest_access.test",
+						tfjsonpath.New("id"),
+						knownvalue.StringExact(groupName+":"+accountName+":watchtower.example.com:22:root"),
+					),
+				},
+			},
+			// Addresses no longer resolved are removed in place
+			{
+				PreConfig: func() {
+					resolver["watchtower.example.com"] = []string{"192.168.1.101"}
+				},
+				Config: testAccGroupGuestAccessResourceConfigHostname(groupName, accountName, "watchtower.example.com"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("bastion_group_guest_access.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"bastion_group_guest_access.test",
+						tfjsonpath.New("resolved_ips"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.StringExact("192.168.1.101"),
+						}),
+					),
+				},
+			},
+		},
+	})
+}
+
 func testAccGroupGuestAccessResourceConfig(group, account, ip, port, user, comment, ttl string) string { // nolint:unparam
 	commentStr := ""
 	if comment != "" {
@@ -411,6 +572,19 @@ resource "bastion_group_guest_access" "test" {
 `, group, account, ip, port, user, commentStr, ttlStr)
 }
 
+func testAccGroupGuestAccessResourceConfigExpiresAt(group, account, expiresAt string) string {
+	return providerConfig + fmt.Sprintf(`
+resource "bastion_group_guest_access" "test" {
+  group      = %[1]q
+  account    = %[2]q
+  ip         = "192.168.1.100"
+  port       = "22"
+  user       = "root"
+  expires_at = %[3]q
+}
+`, group, account, expiresAt)
+}
+
 func testAccGroupGuestAccessResourceConfigWithProtocol(group, account, ip, port, protocol, comment string) string {
 	commentStr := ""
 	if comment != "" {
@@ -448,3 +622,15 @@ resource "bastion_group_guest_access" "test2" {
 }
 `, group, account, ip1, group, account, ip2)
 }
+
+func testAccGroupGuestAccessResourceConfigHostname(group, account, hostname string) string {
+	return providerConfig + fmt.Sprintf(`
+resource "bastion_group_guest_access" "test" {
+  group    = %[1]q
+  account  = %[2]q
+  hostname = %[3]q
+  port     = "22"
+  user     = "root"
+}
+`, group, account, hostname)
+}
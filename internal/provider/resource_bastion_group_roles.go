@@ -0,0 +1,430 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = &GroupRolesResource{}
+var _ resource.ResourceWithImportState = &GroupRolesResource{}
+var _ resource.ResourceWithConfigure = &GroupRolesResource{}
+
+// groupRole describes a role of accounts in a group whose full set is managed by a GroupRolesResource.
+type groupRole struct {
+	// name is the singular name of the role, e.g. "ACL keeper"
+	name string
+	// plural is the plural name of the role, e.g. "ACL keepers"
+	plural string
+	// typeName is the suffix of the resource type name, e.g. "aclkeepers"
+	typeName string
+	// description is appended to the description of the resource
+	description string
+	// accounts returns the accounts having the role in the group
+	accounts func(group *bastion.Group) []string
+	add      func(client *bastion.Client, group, account string) error
+	remove   func(client *bastion.Client, group, account string) error
+	// protectOwners prevents removing the account of the provider and the last account having the role
+	protectOwners bool
+}
+
+var groupOwnersRole = groupRole{
+	name:     "owner",
+	plural:   "owners",
+	typeName: "owners",
+	description: "The `owner` of a `bastion_group` is also managed by that resource, list it in `accounts` or `excluded_accounts` " +
+		"so that both resources do not remove each other's changes. " +
+		"The account of the provider and the last owner of the group are never removed, to keep the group manageable.",
+	accounts:      func(group *bastion.Group) []string { return group.Owners },
+	add:           (*bastion.Client).GroupAddOwner,
+	remove:        (*bastion.Client).GroupRemoveOwner,
+	protectOwners: true,
+}
+
+var groupGatekeepersRole = groupRole{
+	name:     "gatekeeper",
+	plural:   "gatekeepers",
+	typeName: "gatekeepers",
+	accounts: func(group *bastion.Group) []string { return group.Gatekeepers },
+	add:      (*bastion.Client).GroupAddGatekeeper,
+	remove:   (*bastion.Client).GroupRemoveGatekeeper,
+}
+
+var groupACLKeepersRole = groupRole{
+	name:     "ACL keeper",
+	plural:   "ACL keepers",
+	typeName: "aclkeepers",
+	accounts: func(group *bastion.Group) []string { return group.ACLKeepers },
+	add:      (*bastion.Client).GroupAddACLKeeper,
+	remove:   (*bastion.Client).GroupRemoveACLKeeper,
+}
+
+var groupMembersRole = groupRole{
+	name:     "member",
+	plural:   "members",
+	typeName: "members",
+	accounts: func(group *bastion.Group) []string { return group.Members },
+	add:      (*bastion.Client).GroupAddMember,
+	remove:   (*bastion.Client).GroupRemoveMember,
+}
+
+// NewGroupOwnersResource is a helper function to simplify the provider implementation.
+func NewGroupOwnersResource() resource.Resource {
+	return &GroupRolesResource{role: groupOwnersRole}
+}
+
+// NewGroupGatekeepersResource is a helper function to simplify the provider implementation.
+func NewGroupGatekeepersResource() resource.Resource {
+	return &GroupRolesResource{role: groupGatekeepersRole}
+}
+
+// NewGroupACLKeepersResource is a helper function to simplify the provider implementation.
+func NewGroupACLKeepersResource() resource.Resource {
+	return &GroupRolesResource{role: groupACLKeepersRole}
+}
+
+// NewGroupMembersResource is a helper function to simplify the provider implementation.
+func NewGroupMembersResource() resource.Resource {
+	return &GroupRolesResource{role: groupMembersRole}
+}
+
+// GroupRolesResource is the resource implementation, managing the full set of accounts having a role in a group.
+type GroupRolesResource struct {
+	client *bastion.Client
+	role   groupRole
+}
+
+// GroupRolesResourceModel describes the resource data model.
+type GroupRolesResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	Group            types.String `tfsdk:"group"`
+	Accounts         types.Set    `tfsdk:"accounts"`
+	ExcludedAccounts types.Set    `tfsdk:"excluded_accounts"`
+}
+
+// Metadata returns the resource type name.
+func (r *GroupRolesResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_" + r.role.typeName
+}
+
+// Schema defines the schema for the resource.
+func (r *GroupRolesResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	description := fmt.Sprintf("Manages the full set of %[1]s of a Bastion group. "+
+		"%[2]s added outside of this resource are removed on the next apply, unless listed in `excluded_accounts`. "+
+		"Do not use together with `bastion_group_%[3]s` on the same group.",
+		r.role.plural, strings.ToUpper(r.role.plural[:1])+r.role.plural[1:], strings.TrimSuffix(r.role.typeName, "s"))
+	if r.role.description != "" {
+		description += " " + r.role.description
+	}
+
+	accountsValidators := []validator.Set{
+		setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+	}
+	if r.role.protectOwners {
+		accountsValidators = append(accountsValidators, setvalidator.SizeAtLeast(1))
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: description,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The resource identifier (group)",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"group": schema.StringAttribute{
+				MarkdownDescription: "The name of the Bastion group",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"accounts": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: fmt.Sprintf("The accounts which are %s of the group", r.role.plural),
+				Required:            true,
+				Validators:          accountsValidators,
+			},
+			"excluded_accounts": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Accounts which are never added or removed by this resource, e.g. break-glass accounts",
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the bastion client to the resource.
+func (r *GroupRolesResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*bastion.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *bastion.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *GroupRolesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan GroupRolesResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.syncAccounts(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = plan.Group
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *GroupRolesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state GroupRolesResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group, err := r.client.GroupInfo(state.Group.ValueString())
+	if bastion.IsGroupNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Group Information",
+			fmt.Sprintf("Could not read group %s: %s", state.Group.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	var known, excluded []string
+	if !state.Accounts.IsNull() {
+		resp.Diagnostics.Append(state.Accounts.ElementsAs(ctx, &known, false)...)
+	}
+	if !state.ExcludedAccounts.IsNull() {
+		resp.Diagnostics.Append(state.ExcludedAccounts.ElementsAs(ctx, &excluded, false)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// excluded accounts are only tracked if they are also managed
+	accounts := []string{}
+	for _, account := range r.role.accounts(group) {
+		if slices.Contains(excluded, account) && !slices.Contains(known, account) {
+			continue
+		}
+		accounts = append(accounts, account)
+	}
+
+	accountsSet, diags := types.SetValueFrom(ctx, types.StringType, accounts)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Accounts = accountsSet
+	state.ID = state.Group
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *GroupRolesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan GroupRolesResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.syncAccounts(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = plan.Group
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *GroupRolesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state GroupRolesResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var accounts, excluded []string
+	resp.Diagnostics.Append(state.Accounts.ElementsAs(ctx, &accounts, false)...)
+	if !state.ExcludedAccounts.IsNull() {
+		resp.Diagnostics.Append(state.ExcludedAccounts.ElementsAs(ctx, &excluded, false)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var remaining []string
+	if r.role.protectOwners {
+		group, err := r.client.GroupInfo(state.Group.ValueString())
+		if bastion.IsGroupNotFound(err) {
+			return
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Group Information",
+				fmt.Sprintf("Could not read group %s: %s", state.Group.ValueString(), err.Error()),
+			)
+			return
+		}
+		remaining = slices.Clone(r.role.accounts(group))
+	}
+
+	for _, account := range accounts {
+		if slices.Contains(excluded, account) {
+			continue
+		}
+		if r.role.protectOwners {
+			if account == r.client.Username() || len(remaining) == 1 {
+				resp.Diagnostics.AddWarning(
+					fmt.Sprintf("Group %s Kept", titleCase(r.role.name)),
+					fmt.Sprintf("The %s %s of group %s is kept, as removing the account of the provider or the last %s "+
+						"would leave the group unmanageable.", r.role.name, account, state.Group.ValueString(), r.role.name),
+				)
+				continue
+			}
+			remaining = slices.DeleteFunc(remaining, func(owner string) bool { return owner == account })
+		}
+		err := r.role.remove(r.client, state.Group.ValueString(), account)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Error Removing Group %s", titleCase(r.role.name)),
+				fmt.Sprintf("Could not remove %s %s from group %s: %s", r.role.name, account, state.Group.ValueString(), err.Error()),
+			)
+			return
+		}
+	}
+}
+
+// ImportState imports the resource state.
+func (r *GroupRolesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("group"), req, resp)
+}
+
+// syncAccounts adds the planned accounts missing from the role and removes
+// the current accounts having the role which are neither planned nor excluded.
+func (r *GroupRolesResource) syncAccounts(ctx context.Context, plan *GroupRolesResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var accounts, excluded []string
+	diags.Append(plan.Accounts.ElementsAs(ctx, &accounts, false)...)
+	if !plan.ExcludedAccounts.IsNull() {
+		diags.Append(plan.ExcludedAccounts.ElementsAs(ctx, &excluded, false)...)
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	group, err := r.client.GroupInfo(plan.Group.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Error Reading Group Information",
+			fmt.Sprintf("Could not read group %s: %s", plan.Group.ValueString(), err.Error()),
+		)
+		return diags
+	}
+
+	current := r.role.accounts(group)
+	var removed []string
+	for _, account := range current {
+		if slices.Contains(accounts, account) || slices.Contains(excluded, account) {
+			continue
+		}
+		removed = append(removed, account)
+	}
+
+	// Fail before any change, the planned accounts are always kept so the group cannot lose its last owner
+	if r.role.protectOwners && slices.Contains(removed, r.client.Username()) {
+		diags.AddAttributeError(
+			path.Root("accounts"),
+			"Cannot Remove Provider Account",
+			fmt.Sprintf("The account %s used by the provider is an %s of group %s and cannot be removed, "+
+				"add it to accounts or excluded_accounts.", r.client.Username(), r.role.name, plan.Group.ValueString()),
+		)
+		return diags
+	}
+
+	for _, account := range accounts {
+		if slices.Contains(current, account) {
+			continue
+		}
+		err := r.role.add(r.client, plan.Group.ValueString(), account)
+		if err != nil {
+			diags.AddError(
+				fmt.Sprintf("Error Adding Group %s", titleCase(r.role.name)),
+				fmt.Sprintf("Could not add %s %s to group %s: %s", r.role.name, account, plan.Group.ValueString(), err.Error()),
+			)
+			return diags
+		}
+	}
+
+	for _, account := range removed {
+		err := r.role.remove(r.client, plan.Group.ValueString(), account)
+		if err != nil {
+			diags.AddError(
+				fmt.Sprintf("Error Removing Group %s", titleCase(r.role.name)),
+				fmt.Sprintf("Could not remove %s %s from group %s: %s", r.role.name, account, plan.Group.ValueString(), err.Error()),
+			)
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// titleCase returns the name with its words capitalized, as used in diagnostic titles.
+func titleCase(name string) string {
+	words := strings.Fields(name)
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
+}
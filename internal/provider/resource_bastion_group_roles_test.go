@@ -0,0 +1,154 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/adfinis/terraform-provider-bastion/internal/provider/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccGroupOwnersResource(t *testing.T) {
+	testAccGroupRolesResource(t, groupOwnersRole)
+}
+
+func TestAccGroupGatekeepersResource(t *testing.T) {
+	testAccGroupRolesResource(t, groupGatekeepersRole)
+}
+
+func TestAccGroupACLKeepersResource(t *testing.T) {
+	testAccGroupRolesResource(t, groupACLKeepersRole)
+}
+
+func TestAccGroupMembersResource(t *testing.T) {
+	testAccGroupRolesResource(t, groupMembersRole)
+}
+
+// testAccGroupRolesResource tests the authoritative resource of a group role.
+func testAccGroupRolesResource(t *testing.T, role groupRole) {
+	resourceName := "bastion_group_" + role.typeName + ".test"
+	groupName := "testgrp" + role.typeName
+	account1 := "test" + role.typeName + "1"
+	account2 := "test" + role.typeName + "2"
+
+	err := testutils.CreateAccounts(account1, account2)
+	if err != nil {
+		t.Errorf("Unable to create test accounts: %s", err)
+	}
+	err = testutils.CreateGroup(groupName, "bastionadmin", bastion.ED25519)
+	if err != nil {
+		t.Errorf("Unable to create test group: %s", err)
+	}
+
+	t.Cleanup(func() {
+		err := testutils.DeleteGroup(groupName)
+		if err != nil {
+			t.Errorf("Unable to delete test group: %s", err)
+		}
+		err = testutils.DeleteAccounts(account1, account2)
+		if err != nil {
+			t.Errorf("Unable to delete test accounts: %s", err)
+		}
+	})
+
+	steps := []resource.TestStep{
+		// Create and Read testing, the group creator is excluded
+		{
+			Config: testAccGroupRolesResourceConfig(role, groupName, fmt.Sprintf(`[%q]`, account1)),
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue(
+					resourceName,
+					tfjsonpath.New("id"),
+					knownvalue.StringExact(groupName),
+				),
+				statecheck.ExpectKnownValue(
+					resourceName,
+					tfjsonpath.New("accounts"),
+					knownvalue.SetExact([]knownvalue.Check{
+						knownvalue.StringExact(account1),
+					}),
+				),
+			},
+		},
+		// Account added out of band is removed
+		{
+			PreConfig: func() {
+				err := role.add(testutils.TestBastionClient, groupName, account2)
+				if err != nil {
+					t.Errorf("Unable to add %s: %s", role.name, err)
+				}
+			},
+			Config: testAccGroupRolesResourceConfig(role, groupName, fmt.Sprintf(`[%q]`, account1)),
+			ConfigPlanChecks: resource.ConfigPlanChecks{
+				PreApply: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+				},
+			},
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue(
+					resourceName,
+					tfjsonpath.New("accounts"),
+					knownvalue.SetExact([]knownvalue.Check{
+						knownvalue.StringExact(account1),
+					}),
+				),
+			},
+		},
+		// Update testing
+		{
+			Config: testAccGroupRolesResourceConfig(role, groupName, fmt.Sprintf(`[%q, %q]`, account1, account2)),
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue(
+					resourceName,
+					tfjsonpath.New("accounts"),
+					knownvalue.SetExact([]knownvalue.Check{
+						knownvalue.StringExact(account1),
+						knownvalue.StringExact(account2),
+					}),
+				),
+			},
+		},
+	}
+
+	if role.protectOwners {
+		// The account of the provider is never removed
+		steps = append(steps, resource.TestStep{
+			Config: providerConfig + fmt.Sprintf(`
+resource %[1]q "test" {
+  group    = %[2]q
+  accounts = [%[3]q]
+}
+`, "bastion_group_"+role.typeName, groupName, account1),
+			ExpectError: regexp.MustCompile("Cannot Remove Provider Account"),
+		})
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps:                    steps,
+	})
+}
+
+// testAccGroupRolesResourceConfig generates the Terraform configuration for testing.
+func testAccGroupRolesResourceConfig(role groupRole, groupName, accounts string) string {
+	config := providerConfig
+	config += fmt.Sprintf(`
+resource %[1]q "test" {
+  group             = %[2]q
+  accounts          = %[3]s
+  excluded_accounts = ["bastionadmin"]
+}
+`, "bastion_group_"+role.typeName, groupName, accounts)
+
+	return config
+}
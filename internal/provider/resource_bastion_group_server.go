@@ -6,16 +6,19 @@ package provider
 import (
 	"context"
 	"fmt"
-	"strconv"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/adfinis/terraform-provider-bastion/internal/provider/customtypes"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -24,34 +27,58 @@ import (
 var _ resource.Resource = &GroupServerResource{}
 var _ resource.ResourceWithConfigure = &GroupServerResource{}
 var _ resource.ResourceWithImportState = &GroupServerResource{}
+var _ resource.ResourceWithModifyPlan = &GroupServerResource{}
 
 // NewGroupServerResource is a helper function to simplify the provider implementation.
-func NewGroupServerResource() resource.Resource {
-	return &GroupServerResource{}
+// The created resources resolve hostnames with the given resolver.
+func NewGroupServerResource(resolver HostnameResolver) func() resource.Resource {
+	return func() resource.Resource {
+		return &GroupServerResource{resolver: resolver}
+	}
 }
 
 // GroupServerResource is the resource implementation.
 type GroupServerResource struct {
-	client *bastion.Client
+	client   *bastion.Client
+	resolver HostnameResolver
 }
 
 // GroupServerResourceModel describes the resource data model.
 type GroupServerResourceModel struct {
-	ID            types.String `tfsdk:"id"`
-	Group         types.String `tfsdk:"group"`
-	IP            types.String `tfsdk:"ip"`
-	Port          types.String `tfsdk:"port"`
-	User          types.String `tfsdk:"user"`
-	Protocol      types.String `tfsdk:"protocol"`
-	ProxyIP       types.String `tfsdk:"proxy_ip"`
-	ProxyPort     types.String `tfsdk:"proxy_port"`
-	ProxyUser     types.String `tfsdk:"proxy_user"`
-	Comment       types.String `tfsdk:"comment"`
-	ForceKey      types.String `tfsdk:"force_key"`
-	ForcePassword types.String `tfsdk:"force_password"`
-	TTL           types.Int64  `tfsdk:"ttl"`
-	Force         types.Bool   `tfsdk:"force"`
-	RemotePort    types.Int64  `tfsdk:"remote_port"`
+	ID            types.String          `tfsdk:"id"`
+	Group         types.String          `tfsdk:"group"`
+	IP            customtypes.IPAddress `tfsdk:"ip"`
+	Hostname      types.String          `tfsdk:"hostname"`
+	ResolvedIPs   types.List            `tfsdk:"resolved_ips"`
+	Port          types.String          `tfsdk:"port"`
+	User          types.String          `tfsdk:"user"`
+	Protocol      types.String          `tfsdk:"protocol"`
+	ProxyIP       customtypes.IPAddress `tfsdk:"proxy_ip"`
+	ProxyPort     types.String          `tfsdk:"proxy_port"`
+	ProxyUser     types.String          `tfsdk:"proxy_user"`
+	Comment       types.String          `tfsdk:"comment"`
+	ForceKey      types.String          `tfsdk:"force_key"`
+	ForcePassword types.String          `tfsdk:"force_password"`
+	TTL           customtypes.Duration  `tfsdk:"ttl"`
+	Force         types.Bool            `tfsdk:"force"`
+	RemotePort    types.Int64           `tfsdk:"remote_port"`
+	ExpiresAt     types.String          `tfsdk:"expires_at"`
+	OnExpiry      types.String          `tfsdk:"on_expiry"`
+}
+
+// acl returns the attributes the server access shares with the ACL entries of The Bastion.
+func (m *GroupServerResourceModel) acl() accessACL {
+	return accessACL{
+		IP:         &m.IP,
+		Port:       &m.Port,
+		User:       &m.User,
+		Protocol:   &m.Protocol,
+		ProxyIP:    &m.ProxyIP,
+		ProxyPort:  &m.ProxyPort,
+		ProxyUser:  &m.ProxyUser,
+		RemotePort: &m.RemotePort,
+		Comment:    &m.Comment,
+	}
 }
 
 // Metadata returns the resource type name.
@@ -77,12 +104,16 @@ Some features like proxyjump accesses and port forwardings are only support when
 				},
 			},
 			"ip": schema.StringAttribute{
-				MarkdownDescription: "IP or subnet of the access target. (hostname does not work)",
-				Required:            true,
+				CustomType:          customtypes.IPAddressType{},
+				MarkdownDescription: "IP or subnet of the access target. Exactly one of `ip` and `hostname` must be set.",
+				Optional:            true,
 				PlanModifiers: []planmodifier.String{
+					customtypes.IPAddressPlanModifier(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"hostname":     hostnameAttribute(),
+			"resolved_ips": resolvedIPsAttribute(),
 			"port": schema.StringAttribute{
 				MarkdownDescription: "Port of the access target, use '*' to allow ssh access to all ports",
 				Required:            true,
@@ -108,6 +139,7 @@ Some features like proxyjump accesses and port forwardings are only support when
 				},
 			},
 			"proxy_ip": schema.StringAttribute{
+				CustomType:          customtypes.IPAddressType{},
 				MarkdownDescription: "IP of the proxy server",
 				Optional:            true,
 				Validators: []validator.String{
@@ -115,6 +147,7 @@ Some features like proxyjump accesses and port forwardings are only support when
 					stringvalidator.AlsoRequires(path.MatchRoot("proxy_user")),
 				},
 				PlanModifiers: []planmodifier.String{
+					customtypes.IPAddressPlanModifier(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -153,11 +186,14 @@ Some features like proxyjump accesses and port forwardings are only support when
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
-			"ttl": schema.Int64Attribute{
-				MarkdownDescription: "Time to live in seconds for the access",
+			"ttl": schema.StringAttribute{
+				CustomType:          customtypes.DurationType{},
+				MarkdownDescription: "Time to live for the access, as a number of seconds or a duration like `90m` or `7d`",
 				Optional:            true,
-				PlanModifiers: []planmodifier.Int64{
-					int64planmodifier.RequiresReplace(),
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					customtypes.DurationPlanModifier(),
+					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"force": schema.BoolAttribute{
@@ -172,6 +208,8 @@ Some features like proxyjump accesses and port forwardings are only support when
 					int64planmodifier.RequiresReplace(),
 				},
 			},
+			"expires_at": expiresAtAttribute("access"),
+			"on_expiry":  onExpiryAttribute(),
 		},
 	}
 }
@@ -218,106 +256,72 @@ func (r *GroupServerResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
-	// Build options
-	options := &bastion.GroupAddServerOptions{
-		Force: config.Force.ValueBool(),
-	}
-
-	if !plan.ForceKey.IsNull() {
-		options.ForceKey = plan.ForceKey.ValueString()
-	}
-
-	if !plan.ForcePassword.IsNull() {
-		options.ForcePassword = plan.ForcePassword.ValueString()
-	}
-
-	if !plan.Comment.IsNull() {
-		options.Comment = plan.Comment.ValueString()
-	}
-
-	if !plan.TTL.IsNull() {
-		options.TTL = strconv.FormatInt(plan.TTL.ValueInt64(), 10)
-	}
-
-	if !plan.Protocol.IsNull() {
-		options.Protocol = plan.Protocol.ValueString()
-	}
-
-	// Handle proxy options
-	if !plan.ProxyIP.IsNull() || !plan.ProxyPort.IsNull() || !plan.ProxyUser.IsNull() {
-		options.ProxyOptions = &bastion.ProxyOptions{
-			ProxyHost: plan.ProxyIP.ValueString(),
-			ProxyPort: plan.ProxyPort.ValueString(),
-			ProxyUser: plan.ProxyUser.ValueString(),
-		}
+	options, err := groupAddServerOptions(&plan, config.Force.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("expires_at"),
+			"Error Creating Group Server Access",
+			err.Error(),
+		)
+		return
 	}
 
-	if !plan.RemotePort.IsNull() {
-		remotePort := int(plan.RemotePort.ValueInt64())
-		options.RemotePort = &remotePort
+	// The hostname is resolved now when it was unknown at plan time
+	plan.ResolvedIPs, err = resolveUnknownIPs(ctx, r.resolver, plan.Hostname, plan.ResolvedIPs)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("hostname"),
+			"Error Resolving Hostname",
+			err.Error(),
+		)
+		return
 	}
 
-	// Add the server access
-	server, err := r.client.GroupAddServer(
-		plan.Group.ValueString(),
-		plan.IP.ValueString(),
-		plan.Port.ValueString(),
-		plan.User.ValueString(),
-		options,
-	)
+	targets, err := accessTargets(ctx, plan.IP.ValueCanonical(), plan.ResolvedIPs)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error Adding Group Server Access",
-			fmt.Sprintf("Could not add server access to group %s: %s", plan.Group.ValueString(), err.Error()),
+			"Error Creating Group Server Access",
+			err.Error(),
 		)
 		return
 	}
 
-	plan.IP = types.StringValue(server.IP)
-	if server.Port != nil {
-		plan.Port = types.StringValue(server.Port.ValueString())
-	} else {
-		plan.Port = types.StringValue("*")
-	}
-
-	// Handle protocols
-	if server.User != nil && strings.HasPrefix(*server.User, "!") {
-		plan.Protocol = types.StringValue(strings.TrimPrefix(*server.User, "!"))
-		plan.User = types.StringNull()
-	} else if server.User != nil {
-		plan.User = types.StringValue(*server.User)
-		plan.Protocol = types.StringNull()
-	} else {
-		plan.User = types.StringValue("*")
-		plan.Protocol = types.StringNull()
-	}
-
-	if server.ProxyIP != nil {
-		plan.ProxyIP = types.StringValue(*server.ProxyIP)
-	} else {
-		plan.ProxyIP = types.StringNull()
-	}
-	if server.ProxyPort != nil {
-		plan.ProxyPort = types.StringValue(server.ProxyPort.ValueString())
-	} else if !plan.ProxyPort.IsNull() && plan.ProxyPort.ValueString() == "*" {
-		plan.ProxyPort = types.StringValue("*")
-	} else {
-		plan.ProxyPort = types.StringNull()
-	}
-	if server.ProxyUser != nil {
-		plan.ProxyUser = types.StringValue(*server.ProxyUser)
-	} else {
-		plan.ProxyUser = types.StringNull()
+	// Add the server access, once per resolved address of the hostname
+	var server *bastion.GroupServer
+	for i, ip := range targets {
+		added, err := r.client.GroupAddServer(
+			plan.Group.ValueString(),
+			ip,
+			plan.Port.ValueString(),
+			plan.User.ValueString(),
+			options,
+		)
+		if err != nil {
+			// Roll back the accesses already added, the resource is not created
+			for _, addedIP := range targets[:i] {
+				_ = r.delServerAccess(&plan, addedIP)
+			}
+			resp.Diagnostics.AddError(
+				"Error Adding Group Server Access",
+				fmt.Sprintf("Could not add server access to %s to group %s: %s", ip, plan.Group.ValueString(), err.Error()),
+			)
+			return
+		}
+		if server == nil {
+			server = added
+		}
 	}
 
-	if server.RemotePort != nil {
-		plan.RemotePort = types.Int64Value(int64(server.RemotePort.ValueInt()))
+	if plan.Hostname.IsNull() {
+		plan.IP = customtypes.NewIPAddressValue(server.IP)
 	}
+	flattenACL(plan.acl(), (*bastion.ACL)(server))
 
-	if server.Comment != nil {
-		plan.Comment = types.StringValue(*server.Comment)
-	} else {
-		plan.Comment = types.StringNull()
+	if plan.ExpiresAt.IsUnknown() {
+		plan.ExpiresAt = expiresAtValue(server.Expiry)
+		if plan.ExpiresAt.IsNull() {
+			plan.ExpiresAt = expiresAtFromTTL(plan.TTL)
+		}
 	}
 
 	// Generate ID
@@ -345,243 +349,247 @@ func (r *GroupServerResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	// Find matching server access
+	targets, err := accessTargets(ctx, state.IP.ValueCanonical(), state.ResolvedIPs)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Group Server Accesses",
+			err.Error(),
+		)
+		return
+	}
+
+	// Find matching server accesses, one per resolved address of the hostname
 	// Note: API returns null for port, user, proxyPort when set to "*"
 	var found *bastion.GroupServer
-	for _, server := range servers {
-		if matchesServerAccess(&state, server) {
-			found = server
-			break
+	var foundIPs []string
+	for _, ip := range targets {
+		target := state
+		target.IP = customtypes.NewIPAddressValue(ip)
+		for _, server := range servers {
+			if matchesACL(target.acl(), (*bastion.ACL)(server)) {
+				if found == nil {
+					found = server
+				}
+				foundIPs = append(foundIPs, ip)
+				break
+			}
 		}
 	}
 
 	if found == nil {
+		if keepExpiredAccess(state.OnExpiry, state.ExpiresAt) {
+			// The access expired, keep it in the state so that it is not added again
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+			return
+		}
 		resp.State.RemoveResource(ctx)
 		return
 	}
 
 	// Update state from API response
-	state.IP = types.StringValue(found.IP)
-	if found.Port != nil {
-		state.Port = types.StringValue(found.Port.ValueString())
-	} else {
-		state.Port = types.StringValue("*")
-	}
-
-	// Handle protocol
-	if found.User != nil && strings.HasPrefix(*found.User, "!") {
-		// This is a protocol access
-		state.Protocol = types.StringValue(strings.TrimPrefix(*found.User, "!"))
-		state.User = types.StringNull()
-	} else if found.User != nil {
-		state.User = types.StringValue(*found.User)
-		state.Protocol = types.StringNull()
-	} else {
-		state.User = types.StringValue("*")
-		state.Protocol = types.StringNull()
-	}
-
-	if found.ProxyIP != nil {
-		state.ProxyIP = types.StringValue(*found.ProxyIP)
+	if state.Hostname.IsNull() {
+		state.IP = customtypes.NewIPAddressValue(found.IP)
 	} else {
-		state.ProxyIP = types.StringNull()
-	}
-	if found.ProxyPort != nil {
-		state.ProxyPort = types.StringValue(found.ProxyPort.ValueString())
-	} else if !state.ProxyPort.IsNull() && state.ProxyPort.ValueString() == "*" {
-		state.ProxyPort = types.StringValue("*")
-	} else {
-		state.ProxyPort = types.StringNull()
-	}
-	if found.ProxyUser != nil {
-		state.ProxyUser = types.StringValue(*found.ProxyUser)
-	} else {
-		state.ProxyUser = types.StringNull()
+		// Addresses removed outside of Terraform show up as a diff against the resolution
+		resolvedIPs, diags := types.ListValueFrom(ctx, types.StringType, foundIPs)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		state.ResolvedIPs = resolvedIPs
 	}
+	flattenACL(state.acl(), (*bastion.ACL)(found))
 
-	if found.RemotePort != nil {
-		state.RemotePort = types.Int64Value(int64(found.RemotePort.ValueInt()))
-	} else {
-		state.RemotePort = types.Int64Null()
-	}
+	state.ExpiresAt = refreshExpiresAt(state.ExpiresAt, found.Expiry)
 
-	// for some reason comment becomes userComment
-	if found.UserComment != nil {
-		state.Comment = types.StringValue(*found.UserComment)
-	} else {
-		state.Comment = types.StringNull()
+	// on_expiry is not known after an import
+	if state.OnExpiry.IsNull() {
+		state.OnExpiry = types.StringValue(onExpiryRecreate)
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
-// Update updates the resource and sets the updated Terraform state on success.
-func (r *GroupServerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError(
-		"Update Not Supported",
-		"Group server accesses cannot be updated. This is a bug in the provider.",
-	)
+// ModifyPlan resolves the hostname, validates the expiry of the access and rejects plans keeping an expired access when
+// on_expiry is set to "error".
+func (r *GroupServerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planResolvedIPs(ctx, r.resolver, req, resp)
+	checkExpiresAtPlan(ctx, req, resp)
+	checkExpiredAccessPlan(ctx, req, resp)
 }
 
-func (r *GroupServerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state GroupServerResourceModel
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *GroupServerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state, config GroupServerResourceModel
 
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Build proxy options if needed
-	var proxyOpts *bastion.ProxyOptions
-	if !state.ProxyIP.IsNull() {
-		proxyOpts = &bastion.ProxyOptions{
-			ProxyHost: state.ProxyIP.ValueString(),
-			ProxyPort: state.ProxyPort.ValueString(),
-			ProxyUser: state.ProxyUser.ValueString(),
-		}
-	}
+	// All other attributes require replacement, only on_expiry and the resolved addresses of the hostname can change
+	plan.ID = state.ID
+	plan.ExpiresAt = state.ExpiresAt
 
-	err := r.client.GroupDelServer(
-		state.Group.ValueString(),
-		state.IP.ValueString(),
-		state.Port.ValueString(),
-		state.User.ValueString(),
-		state.Protocol.ValueString(),
-		proxyOpts,
-		state.RemotePort.ValueInt64Pointer(),
-	)
+	priorTargets, err := accessTargets(ctx, state.IP.ValueCanonical(), state.ResolvedIPs)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error Deleting Group Server Access",
-			fmt.Sprintf("Could not delete server access from group %s: %s", state.Group.ValueString(), err.Error()),
+			"Error Updating Group Server Access",
+			err.Error(),
 		)
 		return
 	}
-}
 
-// ImportState imports the resource state.
-func (r *GroupServerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Expected formats:
-	// - group:ip:port:user
-	// - group:ip:port:user:protocol
-	// - group:ip:port:user:protocol:remote_port (when protocol is "portforward")
-	// - group:ip:port:user:proxy_ip:proxy_port:proxy_user
-	// - group:ip:port:user:protocol:proxy_ip:proxy_port:proxy_user
-	// - group:ip:port:user:protocol:remote_port:proxy_ip:proxy_port:proxy_user (when protocol is "portforward")
-	importID := req.ID
-	parts := parseImportID(importID)
-
-	if len(parts) < 4 || len(parts) > 9 {
+	// The hostname is resolved now when it was unknown at plan time
+	plan.ResolvedIPs, err = resolveUnknownIPs(ctx, r.resolver, plan.Hostname, plan.ResolvedIPs)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("hostname"),
+			"Error Resolving Hostname",
+			err.Error(),
+		)
+		return
+	}
+
+	targets, err := accessTargets(ctx, plan.IP.ValueCanonical(), plan.ResolvedIPs)
+	if err != nil {
 		resp.Diagnostics.AddError(
-			"Invalid Import ID",
-			fmt.Sprintf("Expected import ID in the format 'group:ip:port:user', 'group:ip:port:user:protocol', 'group:ip:port:user:protocol:remote_port', 'group:ip:port:user:proxy_ip:proxy_port:proxy_user', 'group:ip:port:user:protocol:proxy_ip:proxy_port:proxy_user', or 'group:ip:port:user:protocol:remote_port:proxy_ip:proxy_port:proxy_user', got: %s", importID),
+			"Error Updating Group Server Access",
+			err.Error(),
 		)
 		return
 	}
+	added, removed := diffTargets(priorTargets, targets)
+	if accessExpired(state.ExpiresAt) {
+		// The Bastion already removed the expired accesses, they are not granted again
+		added = nil
+	}
 
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group"), parts[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("ip"), parts[1])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("port"), parts[2])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user"), parts[3])...)
+	// Added addresses get the same expiry as the existing accesses
+	options, err := groupAddServerOptions(&plan, config.Force.ValueBool())
+	if len(added) > 0 && err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("expires_at"),
+			"Error Updating Group Server Access",
+			err.Error(),
+		)
+		return
+	}
 
-	if len(parts) == 5 {
-		// group:ip:port:user:protocol
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("protocol"), parts[4])...)
-	} else if len(parts) == 6 {
-		// group:ip:port:user:protocol:remote_port (when protocol is "portforward")
-		protocol := parts[4]
-		if protocol != "portforward" {
+	granted := slices.Clone(priorTargets)
+	for _, ip := range added {
+		if _, err := r.client.GroupAddServer(
+			plan.Group.ValueString(),
+			ip,
+			plan.Port.ValueString(),
+			plan.User.ValueString(),
+			options,
+		); err != nil {
 			resp.Diagnostics.AddError(
-				"Invalid Import ID",
-				fmt.Sprintf("When specifying remote_port, protocol must be 'portforward', got: %s", protocol),
+				"Error Adding Group Server Access",
+				fmt.Sprintf("Could not add server access to %s to group %s: %s", ip, plan.Group.ValueString(), err.Error()),
 			)
+			r.setGrantedTargets(ctx, resp, &state, granted)
 			return
 		}
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("protocol"), protocol)...)
-		remotePort, err := strconv.ParseInt(parts[5], 10, 64)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Invalid Import ID",
-				fmt.Sprintf("Invalid remote_port value '%s': %s", parts[5], err.Error()),
-			)
-			return
-		}
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("remote_port"), remotePort)...)
-	} else if len(parts) == 7 {
-		// group:ip:port:user:proxy_ip:proxy_port:proxy_user
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("proxy_ip"), parts[4])...)
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("proxy_port"), parts[5])...)
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("proxy_user"), parts[6])...)
-	} else if len(parts) == 8 {
-		// group:ip:port:user:protocol:proxy_ip:proxy_port:proxy_user
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("protocol"), parts[4])...)
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("proxy_ip"), parts[5])...)
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("proxy_port"), parts[6])...)
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("proxy_user"), parts[7])...)
-	} else if len(parts) == 9 {
-		// group:ip:port:user:protocol:remote_port:proxy_ip:proxy_port:proxy_user
-		protocol := parts[4]
-		if protocol != "portforward" {
+		granted = append(granted, ip)
+	}
+
+	for _, ip := range removed {
+		if err := r.delServerAccess(&state, ip); err != nil && !accessExpired(state.ExpiresAt) {
 			resp.Diagnostics.AddError(
-				"Invalid Import ID",
-				fmt.Sprintf("When specifying remote_port with proxy, protocol must be 'portforward', got: %s", protocol),
+				"Error Deleting Group Server Access",
+				fmt.Sprintf("Could not delete server access to %s from group %s: %s", ip, state.Group.ValueString(), err.Error()),
 			)
+			r.setGrantedTargets(ctx, resp, &state, granted)
 			return
 		}
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("protocol"), protocol)...)
-		remotePort, err := strconv.ParseInt(parts[5], 10, 64)
-		if err != nil {
+		granted = slices.DeleteFunc(granted, func(g string) bool { return g == ip })
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// setGrantedTargets saves the prior state with the addresses the access is granted to after a partial update.
+func (r *GroupServerResource) setGrantedTargets(ctx context.Context, resp *resource.UpdateResponse, state *GroupServerResourceModel, granted []string) {
+	if state.Hostname.IsNull() {
+		return
+	}
+
+	resolvedIPs, diags := types.ListValueFrom(ctx, types.StringType, granted)
+	resp.Diagnostics.Append(diags...)
+	state.ResolvedIPs = resolvedIPs
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *GroupServerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state GroupServerResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	targets, err := accessTargets(ctx, state.IP.ValueCanonical(), state.ResolvedIPs)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Group Server Access",
+			err.Error(),
+		)
+		return
+	}
+
+	for _, ip := range targets {
+		err := r.delServerAccess(&state, ip)
+		if err != nil && !accessExpired(state.ExpiresAt) {
 			resp.Diagnostics.AddError(
-				"Invalid Import ID",
-				fmt.Sprintf("Invalid remote_port value '%s': %s", parts[5], err.Error()),
+				"Error Deleting Group Server Access",
+				fmt.Sprintf("Could not delete server access from group %s: %s", state.Group.ValueString(), err.Error()),
 			)
 			return
 		}
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("remote_port"), remotePort)...)
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("proxy_ip"), parts[6])...)
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("proxy_port"), parts[7])...)
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("proxy_user"), parts[8])...)
 	}
-
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), importID)...)
 }
 
-// generateServerAccessID generates a unique ID for a server access.
-// IPv6 addresses are wrapped in brackets to distinguish colons in the address from delimiter colons.
-func generateServerAccessID(model *GroupServerResourceModel) string {
-	ip := formatIPForID(model.IP.ValueString())
-	proxyIP := ""
+// delServerAccess removes the access to the given address from the group.
+func (r *GroupServerResource) delServerAccess(model *GroupServerResourceModel, ip string) error {
+	// Build proxy options if needed
+	var proxyOpts *bastion.ProxyOptions
 	if !model.ProxyIP.IsNull() {
-		proxyIP = formatIPForID(model.ProxyIP.ValueString())
+		proxyOpts = &bastion.ProxyOptions{
+			ProxyHost: model.ProxyIP.ValueCanonical(),
+			ProxyPort: model.ProxyPort.ValueString(),
+			ProxyUser: model.ProxyUser.ValueString(),
+		}
 	}
 
-	id := fmt.Sprintf("%s:%s:%s:%s",
+	return r.client.GroupDelServer(
 		model.Group.ValueString(),
 		ip,
 		model.Port.ValueString(),
 		model.User.ValueString(),
+		model.Protocol.ValueString(),
+		proxyOpts,
+		model.RemotePort.ValueInt64Pointer(),
 	)
+}
 
-	// Add protocol if present
-	if !model.Protocol.IsNull() {
-		id = fmt.Sprintf("%s:%s", id, model.Protocol.ValueString())
-	}
-
-	// Add remote_port if present (only valid with portforward protocol)
-	if !model.RemotePort.IsNull() {
-		id = fmt.Sprintf("%s:%d", id, model.RemotePort.ValueInt64())
-	}
+// ImportState imports the resource state.
+func (r *GroupServerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importAccessACL(ctx, req.ID, []string{"group"}, resp)
+}
 
-	if !model.ProxyIP.IsNull() {
-		id = fmt.Sprintf("%s:%s:%s:%s",
-			id,
-			proxyIP,
-			model.ProxyPort.ValueString(),
-			model.ProxyUser.ValueString(),
-		)
+// generateServerAccessID generates a unique ID for a server access, using the hostname instead of the IP when set.
+// IPv6 addresses are wrapped in brackets to distinguish colons in the address from delimiter colons.
+func generateServerAccessID(model *GroupServerResourceModel) string {
+	target := formatIPForID(model.IP.ValueString())
+	if !model.Hostname.IsNull() {
+		target = model.Hostname.ValueString()
 	}
-
-	return id
+	return accessACLID([]string{model.Group.ValueString()}, target, model.acl())
 }
 
 // parseImportID parses an import ID into its components.
@@ -623,88 +631,245 @@ func formatIPForID(ip string) string {
 	return ip
 }
 
-// matchesServerAccess checks if a server access matches the state.
-func matchesServerAccess(state *GroupServerResourceModel, server *bastion.GroupServer) bool {
-	if state.IP.ValueString() != server.IP {
-		return false
+// groupAddServerOptions builds the options to add the server access of the model.
+func groupAddServerOptions(model *GroupServerResourceModel, force bool) (*bastion.GroupAddServerOptions, error) {
+	options := &bastion.GroupAddServerOptions{
+		Force: force,
 	}
 
-	// Check port (API returns null for "*")
-	statePort := state.Port.ValueString()
-	serverPort := "*"
-	if server.Port != nil {
-		serverPort = server.Port.ValueString()
+	if !model.ForceKey.IsNull() {
+		options.ForceKey = model.ForceKey.ValueString()
 	}
-	if statePort != serverPort {
-		return false
+
+	if !model.ForcePassword.IsNull() {
+		options.ForcePassword = model.ForcePassword.ValueString()
 	}
 
-	// Check user (API returns null for "*")
-	// For protocol accesses, API returns username as "!protocol"
-	stateUser := state.User.ValueString()
-	stateProtocol := ""
-	if !state.Protocol.IsNull() {
-		stateProtocol = state.Protocol.ValueString()
+	if !model.Comment.IsNull() {
+		options.Comment = model.Comment.ValueString()
 	}
 
-	serverUser := "*"
-	if server.User != nil {
-		serverUser = *server.User
+	if !model.TTL.IsNull() {
+		options.TTL = model.TTL.ValueDuration()
 	}
 
-	// If state has a protocol, match against "!protocol" in server user
-	if stateProtocol != "" {
-		expectedUser := "!" + stateProtocol
-		if serverUser != expectedUser {
-			return false
+	if !model.ExpiresAt.IsNull() && !model.ExpiresAt.IsUnknown() {
+		ttl, err := ttlFromExpiresAt(model.ExpiresAt)
+		if err != nil {
+			return nil, err
 		}
-	} else {
-		if stateUser != serverUser {
-			return false
+		options.TTL = ttl
+	}
+
+	if !model.Protocol.IsNull() {
+		options.Protocol = model.Protocol.ValueString()
+	}
+
+	// Handle proxy options
+	if !model.ProxyIP.IsNull() || !model.ProxyPort.IsNull() || !model.ProxyUser.IsNull() {
+		options.ProxyOptions = &bastion.ProxyOptions{
+			ProxyHost: model.ProxyIP.ValueCanonical(),
+			ProxyPort: model.ProxyPort.ValueString(),
+			ProxyUser: model.ProxyUser.ValueString(),
 		}
 	}
 
-	// Check remote_port (for portforward protocol)
-	stateHasRemotePort := !state.RemotePort.IsNull()
-	serverHasRemotePort := server.RemotePort != nil
+	if !model.RemotePort.IsNull() {
+		remotePort := int(model.RemotePort.ValueInt64())
+		options.RemotePort = &remotePort
+	}
 
-	if stateHasRemotePort != serverHasRemotePort {
-		return false
+	return options, nil
+}
+
+// Behaviors of temporary accesses once The Bastion removed them after their expiry.
+const (
+	onExpiryRecreate   = "recreate"
+	onExpiryKeepAbsent = "keep_absent"
+	onExpiryError      = "error"
+)
+
+// expiresAtTolerance is the maximum difference between a configured expiry timestamp and the one reported by The Bastion.
+// The TTL is computed at apply time, so the expiry on The Bastion can be off by a few seconds.
+const expiresAtTolerance = time.Minute
+
+// expiresAtAttribute returns the schema of the expiry timestamp of an access or account.
+func expiresAtAttribute(subject string) schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: fmt.Sprintf("RFC 3339 timestamp at which the %[1]s expires, e.g. `2026-11-01T18:00:00Z`. "+
+			"Alternative to `ttl`, the remaining time to live is computed at apply time. "+
+			"Reported by The Bastion when `ttl` is used, null if the %[1]s does not expire.", subject),
+		Optional: true,
+		Computed: true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+			stringplanmodifier.RequiresReplaceIfConfigured(),
+		},
+		Validators: []validator.String{
+			stringvalidator.ConflictsWith(path.MatchRoot("ttl")),
+		},
 	}
+}
 
-	if stateHasRemotePort {
-		if state.RemotePort.ValueInt64() != int64(server.RemotePort.ValueInt()) {
-			return false
-		}
+// onExpiryAttribute returns the schema of the behavior of an access once it expired.
+func onExpiryAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "Behavior once The Bastion removed the access after it expired. " +
+			"`recreate` adds the access again on the next apply, `keep_absent` keeps the expired access in the state without adding it again, " +
+			"`error` fails the plan until the access is removed from the configuration or replaced. Defaults to `recreate`.",
+		Optional: true,
+		Computed: true,
+		Default:  stringdefault.StaticString(onExpiryRecreate),
+		Validators: []validator.String{
+			stringvalidator.OneOf(onExpiryRecreate, onExpiryKeepAbsent, onExpiryError),
+		},
+	}
+}
+
+// expiresAtValue converts the expiry of an ACL to an RFC 3339 timestamp.
+func expiresAtValue(expiry *int) types.String {
+	if expiry == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(time.Unix(int64(*expiry), 0).UTC().Format(time.RFC3339))
+}
+
+// expiresAtFromTTL computes the expiry timestamp of an access added now with the given TTL.
+func expiresAtFromTTL(ttl customtypes.Duration) types.String {
+	if ttl.IsNull() || ttl.IsUnknown() {
+		return types.StringNull()
+	}
+	return types.StringValue(time.Now().Add(time.Duration(ttl.ValueDuration().Seconds()) * time.Second).UTC().Format(time.RFC3339))
+}
+
+// refreshExpiresAt converts the expiry of an ACL to an RFC 3339 timestamp.
+// The prior value is kept when it designates the same time, to avoid diffs with the configured timestamp.
+func refreshExpiresAt(prior types.String, expiry *int) types.String {
+	value := expiresAtValue(expiry)
+	if value.IsNull() || prior.IsNull() || prior.IsUnknown() {
+		return value
+	}
+
+	t, err := time.Parse(time.RFC3339, prior.ValueString())
+	if err != nil {
+		return value
+	}
+	if time.Unix(int64(*expiry), 0).Sub(t).Abs() <= expiresAtTolerance {
+		return prior
+	}
+	return value
+}
+
+// ttlFromExpiresAt computes the remaining time to live until the expiry timestamp.
+func ttlFromExpiresAt(expiresAt types.String) (bastion.Duration, error) {
+	t, err := time.Parse(time.RFC3339, expiresAt.ValueString())
+	if err != nil {
+		return 0, err
 	}
 
-	// Check proxy settings
-	stateHasProxy := !state.ProxyIP.IsNull()
-	serverHasProxy := server.ProxyIP != nil
+	remaining := time.Until(t)
+	if remaining <= 0 {
+		return 0, fmt.Errorf("expiry timestamp %s lies in the past", expiresAt.ValueString())
+	}
+	return bastion.Duration((remaining + time.Second - 1) / time.Second), nil
+}
 
-	if stateHasProxy != serverHasProxy {
+// accessExpired checks if the expiry timestamp of an access lies in the past.
+func accessExpired(expiresAt types.String) bool {
+	if expiresAt.IsNull() || expiresAt.IsUnknown() {
 		return false
 	}
 
-	if stateHasProxy {
-		if state.ProxyIP.ValueString() != *server.ProxyIP {
-			return false
-		}
+	t, err := time.Parse(time.RFC3339, expiresAt.ValueString())
+	if err != nil {
+		return false
+	}
+	return !t.After(time.Now())
+}
 
-		// Check proxy port (API returns null for "*")
-		stateProxyPort := state.ProxyPort.ValueString()
-		serverProxyPort := "*"
-		if server.ProxyPort != nil {
-			serverProxyPort = server.ProxyPort.ValueString()
-		}
-		if stateProxyPort != serverProxyPort {
-			return false
-		}
+// keepExpiredAccess checks if an access missing on The Bastion should be kept in the state.
+func keepExpiredAccess(onExpiry, expiresAt types.String) bool {
+	if onExpiry.IsNull() || onExpiry.ValueString() == onExpiryRecreate {
+		return false
+	}
+	return accessExpired(expiresAt)
+}
 
-		if state.ProxyUser.ValueString() != *server.ProxyUser {
-			return false
+// checkExpiredAccessPlan adds an error when an expired access with on_expiry set to "error" is kept by the plan.
+func checkExpiredAccessPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on create or destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var onExpiry, plannedExpiresAt, expiresAt types.String
+	var plannedTTL, ttl customtypes.Duration
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("on_expiry"), &onExpiry)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("expires_at"), &plannedExpiresAt)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("ttl"), &plannedTTL)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("expires_at"), &expiresAt)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("ttl"), &ttl)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if onExpiry.ValueString() != onExpiryError || !accessExpired(expiresAt) {
+		return
+	}
+
+	// Changing ttl or expires_at replaces the access, which grants it again. The attribute plan modifiers requesting
+	// the replacement are not reported in resp.RequiresReplace, so the plan is compared with the state.
+	if !plannedTTL.Equal(ttl) || !plannedExpiresAt.Equal(expiresAt) {
+		return
+	}
+
+	resp.Diagnostics.AddError(
+		"Access Expired",
+		fmt.Sprintf("The access expired at %s. Remove it from the configuration, or change its ttl or expires_at to grant it again.", expiresAt.ValueString()),
+	)
+}
+
+// checkExpiresAtPlan validates the configured expiry timestamp, rejecting timestamps in the past unless they are the
+// prior expiry of the access or account.
+func checkExpiresAtPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var expiresAt types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("expires_at"), &expiresAt)...)
+	if resp.Diagnostics.HasError() || expiresAt.IsNull() || expiresAt.IsUnknown() {
+		return
+	}
+
+	t, err := time.Parse(time.RFC3339, expiresAt.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("expires_at"),
+			"Invalid Expiry Timestamp",
+			fmt.Sprintf("Expected an RFC 3339 timestamp like 2026-11-01T18:00:00Z, got: %s", expiresAt.ValueString()),
+		)
+		return
+	}
+
+	// Existing resources keeping their expiry timestamp once it passed are handled by on_expiry
+	if !req.State.Raw.IsNull() {
+		var prior types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("expires_at"), &prior)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if priorTime, err := time.Parse(time.RFC3339, prior.ValueString()); err == nil && priorTime.Equal(t) {
+			return
 		}
 	}
 
-	return true
+	if !t.After(time.Now()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("expires_at"),
+			"Invalid Expiry Timestamp",
+			fmt.Sprintf("The expiry timestamp %s lies in the past.", expiresAt.ValueString()),
+		)
+	}
 }
@@ -4,16 +4,26 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/adfinis/terraform-provider-bastion/internal/provider/customtypes"
 	"github.com/adfinis/terraform-provider-bastion/internal/provider/testutils"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccGroupServerResource(t *testing.T) {
@@ -347,6 +357,68 @@ func TestAccGroupServerResource_Subnet(t *testing.T) {
 	})
 }
 
+func TestAccGroupServerResource_CanonicalIP(t *testing.T) {
+	err := testutils.CreateGroup("testgrpsrv15", "bastionadmin", bastion.ED25519)
+	if err != nil {
+		t.Errorf("Unable to create test group: %s", err)
+	}
+
+	t.Cleanup(func() {
+		err := testutils.DeleteGroup("testgrpsrv15")
+		if err != nil {
+			t.Errorf("Unable to delete test group: %s", err)
+		}
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Hostnames are rejected, use the hostname attribute instead
+			{
+				Config:      testAccGroupServerResourceConfig("testgrpsrv15", "fortress.example.com", "22", "root", "", "", "", ""),
+				ExpectError: regexp.MustCompile("Invalid IP Address"),
+			},
+			// The configured form is kept, The Bastion stores the canonical one
+			{
+				Config: testAccGroupServerResourceConfig("testgrpsrv15", "192.168.1.170/32", "22", "root", "", "", "", ""),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"bastion_group_server.test",
+						tfjsonpath.New("ip"),
+						knownvalue.StringExact("192.168.1.170/32"),
+					),
+					statecheck.ExpectKnownValue(
+						"bastion_group_server.test",
+						tfjsonpath.New("id"),
+						knownvalue.StringExact("testgrpsrv15:192.168.1.170:22:root"),
+					),
+				},
+			},
+			// Semantically equal addresses give no diff
+			{
+				Config: testAccGroupServerResourceConfig("testgrpsrv15", "192.168.1.170/32", "22", "root", "", "", "", ""),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// Uncompressed IPv6 addresses are accepted
+			{
+				Config: testAccGroupServerResourceConfig("testgrpsrv15", "2001:0db8:0000:0000:0000:0000:0000:0170", "22", "root", "", "", "", ""),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"bastion_group_server.test",
+						tfjsonpath.New("id"),
+						knownvalue.StringExact("testgrpsrv15:[2001:db8::170]:22:root"),
+					),
+				},
+			},
+		},
+	})
+}
+
 func TestAccGroupServerResource_WithTTL(t *testing.T) {
 	err := testutils.CreateGroup("testgrpsrv10", "bastionadmin", bastion.ED25519)
 	if err != nil {
@@ -391,13 +463,220 @@ func TestAccGroupServerResource_WithTTL(t *testing.T) {
 					statecheck.ExpectKnownValue(
 						"bastion_group_server.test",
 						tfjsonpath.New("ttl"),
-						knownvalue.Int64Exact(3600),
+						knownvalue.StringExact("3600"),
 					),
 					statecheck.ExpectKnownValue(
 						"bastion_group_server.test",
 						tfjsonpath.New("id"),
 						knownvalue.StringExact("testgrpsrv10:192.168.1.150:22:root"),
 					),
+					statecheck.ExpectKnownValue(
+						"bastion_group_server.test",
+						tfjsonpath.New("expires_at"),
+						knownvalue.NotNull(),
+					),
+					statecheck.ExpectKnownValue(
+						"bastion_group_server.test",
+						tfjsonpath.New("on_expiry"),
+						knownvalue.StringExact("recreate"),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccGroupServerResource_ExpiresAt(t *testing.T) {
+	expiresAt := time.Now().Add(2 * time.Hour).UTC().Truncate(time.Second).Format(time.RFC3339)
+	past := time.Now().Add(-time.Hour).UTC().Truncate(time.Second).Format(time.RFC3339)
+
+	err := testutils.CreateGroup("testgrpsrv13", "bastionadmin", bastion.ED25519)
+	if err != nil {
+		t.Errorf("Unable to create test group: %s", err)
+	}
+
+	t.Cleanup(func() {
+		err := testutils.DeleteGroup("testgrpsrv13")
+		if err != nil {
+			t.Errorf("Unable to delete test group: %s", err)
+		}
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Timestamps in the past are rejected at plan time
+			{
+				Config:      testAccGroupServerResourceConfigExpiresAt("testgrpsrv13", past),
+				ExpectError: regexp.MustCompile("lies in the past"),
+			},
+			{
+				Config: testAccGroupServerResourceConfigExpiresAt("testgrpsrv13", expiresAt),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"bastion_group_server.test",
+						tfjsonpath.New("expires_at"),
+						knownvalue.StringExact(expiresAt),
+					),
+					statecheck.ExpectKnownValue(
+						"bastion_group_server.test",
+						tfjsonpath.New("ttl"),
+						knownvalue.Null(),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccGroupServerResource_OnExpiry(t *testing.T) {
+	err := testutils.CreateGroup("testgrpsrv12", "bastionadmin", bastion.ED25519)
+	if err != nil {
+		t.Errorf("Unable to create test group: %s", err)
+	}
+
+	t.Cleanup(func() {
+		err := testutils.DeleteGroup("testgrpsrv12")
+		if err != nil {
+			t.Errorf("Unable to delete test group: %s", err)
+		}
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create a short lived access
+			{
+				Config: testAccGroupServerResourceConfigOnExpiry("testgrpsrv12", 5, "keep_absent"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"bastion_group_server.test",
+						tfjsonpath.New("expires_at"),
+						knownvalue.NotNull(),
+					),
+					statecheck.ExpectKnownValue(
+						"bastion_group_server.test",
+						tfjsonpath.New("on_expiry"),
+						knownvalue.StringExact("keep_absent"),
+					),
+				},
+			},
+			// The expired access is not added again
+			{
+				PreConfig: func() {
+					time.Sleep(10 * time.Second)
+				},
+				Config: testAccGroupServerResourceConfigOnExpiry("testgrpsrv12", 5, "keep_absent"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// The expired access fails the plan
+			{
+				Config:      testAccGroupServerResourceConfigOnExpiry("testgrpsrv12", 5, "error"),
+				ExpectError: regexp.MustCompile("Access Expired"),
+			},
+			// Changing the ttl grants the access again
+			{
+				Config: testAccGroupServerResourceConfigOnExpiry("testgrpsrv12", 3600, "error"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("bastion_group_server.test", plancheck.ResourceActionReplace),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccGroupServerResource_Hostname(t *testing.T) {
+	resolver := StaticResolver{
+		"fortress.example.com": {"192.168.1.161", "192.168.1.160"},
+	}
+
+	err := testutils.CreateGroup("testgrpsrv14", "bastionadmin", bastion.ED25519)
+	if err != nil {
+		t.Errorf("Unable to create test group: %s", err)
+	}
+
+	t.Cleanup(func() {
+		err := testutils.DeleteGroup("testgrpsrv14")
+		if err != nil {
+			t.Errorf("Unable to delete test group: %s", err)
+		}
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithResolver(resolver),
+		Steps: []resource.TestStep{
+			// Either ip or hostname must be set
+			{
+				Config:      testAccGroupServerResourceConfigHostname("testgrpsrv14", ""),
+				ExpectError: regexp.MustCompile("Invalid Attribute Combination"),
+			},
+			// Unresolvable hostnames are rejected at plan time
+			{
+				Config:      testAccGroupServerResourceConfigHostname("testgrpsrv14", "kandor.example.com"),
+				ExpectError: regexp.MustCompile("Error Resolving Hostname"),
+			},
+			// One access is added per resolved address
+			{
+				Config: testAccGroupServerResourceConfigHostname("testgrpsrv14", "fortress.example.com"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"bastion_group_server.test",
+						tfjsonpath.New("ip"),
+						knownvalue.Null(),
+					),
+					statecheck.ExpectKnownValue(
+						"bastion_group_server.test",
+						tfjsonpath.New("resolved_ips"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.StringExact("192.168.1.160"),
+							knownvalue.StringExact("192.168.1.161"),
+						}),
+					),
+					statecheck.ExpectKnownValue(
+						"bastion_group_server.test",
+						tfjsonpath.New("id"),
+						knownvalue.StringExact("testgrpsrv14:fortress.example.com:22:root"),
+					),
+				},
+			},
+			// Changes in the resolution are applied in place
+			{
+				PreConfig: func() {
+					resolver["fortress.example.com"] = []string{"192.168.1.161", "192.168.1.162"}
+				},
+				Config: testAccGroupServerResourceConfigHostname("testgrpsrv14", "fortress.example.com"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("bastion_group_server.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"bastion_group_server.test",
+						tfjsonpath.New("resolved_ips"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.StringExact("192.168.1.161"),
+							knownvalue.StringExact("192.168.1.162"),
+						}),
+					),
+				},
+			},
+			// An unchanged resolution gives no diff
+			{
+				Config: testAccGroupServerResourceConfigHostname("testgrpsrv14", "fortress.example.com"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
 				},
 			},
 		},
@@ -806,6 +1085,62 @@ resource "bastion_group_server" "test" {
 	return config
 }
 
+// testAccGroupServerResourceConfigExpiresAt generates config with expires_at.
+func testAccGroupServerResourceConfigExpiresAt(groupName, expiresAt string) string {
+	config := providerConfig
+	config += fmt.Sprintf(`
+resource "bastion_group_server" "test" {
+  group      = %[1]q
+  ip         = "192.168.1.152"
+  port       = "22"
+  user       = "root"
+  expires_at = %[2]q
+  force      = true
+}
+`, groupName, expiresAt)
+
+	return config
+}
+
+// testAccGroupServerResourceConfigOnExpiry generates config with ttl and on_expiry.
+func testAccGroupServerResourceConfigOnExpiry(groupName string, ttl int64, onExpiry string) string {
+	config := providerConfig
+	config += fmt.Sprintf(`
+resource "bastion_group_server" "test" {
+  group     = %[1]q
+  ip        = "192.168.1.151"
+  port      = "22"
+  user      = "root"
+  ttl       = %[2]d
+  on_expiry = %[3]q
+  force     = true
+}
+`, groupName, ttl, onExpiry)
+
+	return config
+}
+
+// testAccGroupServerResourceConfigHostname generates config with a hostname instead of an ip.
+func testAccGroupServerResourceConfigHostname(groupName, hostname string) string {
+	hostnameStr := ""
+	if hostname != "" {
+		hostnameStr = fmt.Sprintf(`hostname = %q`, hostname)
+	}
+
+	config := providerConfig
+	config += fmt.Sprintf(`
+resource "bastion_group_server" "test" {
+  group = %[1]q
+  port  = "22"
+  user  = "root"
+  force = true
+  %[2]s
+}
+`, groupName, hostnameStr)
+
+	return config
+}
+
 // testAccGroupServerResourceConfigWithForceKey generates config with force_key.
 func testAccGroupServerResourceConfigWithForceKey(groupName, ip, port, user, forceKey string) string {
 	config := providerConfig
@@ -1531,3 +1866,346 @@ resource "bastion_group_server" "pf5432" {
 
 	return config
 }
+
+func TestAccessExpiredAt(t *testing.T) {
+	testCases := []struct {
+		name      string
+		expiresAt types.String
+		expected  bool
+	}{
+		{
+			name:      "no expiry",
+			expiresAt: types.StringNull(),
+			expected:  false,
+		},
+		{
+			name:      "unknown expiry",
+			expiresAt: types.StringUnknown(),
+			expected:  false,
+		},
+		{
+			name:      "past expiry",
+			expiresAt: types.StringValue(time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)),
+			expected:  true,
+		},
+		{
+			name:      "future expiry",
+			expiresAt: types.StringValue(time.Now().Add(time.Hour).UTC().Format(time.RFC3339)),
+			expected:  false,
+		},
+		{
+			name:      "invalid expiry",
+			expiresAt: types.StringValue("tomorrow"),
+			expected:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, accessExpired(tc.expiresAt))
+		})
+	}
+}
+
+func TestKeepExpiredAccess(t *testing.T) {
+	past := types.StringValue(time.Now().Add(-time.Hour).UTC().Format(time.RFC3339))
+	future := types.StringValue(time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+
+	testCases := []struct {
+		name      string
+		onExpiry  types.String
+		expiresAt types.String
+		expected  bool
+	}{
+		{
+			name:      "recreate expired access",
+			onExpiry:  types.StringValue("recreate"),
+			expiresAt: past,
+			expected:  false,
+		},
+		{
+			name:      "keep absent expired access",
+			onExpiry:  types.StringValue("keep_absent"),
+			expiresAt: past,
+			expected:  true,
+		},
+		{
+			name:      "error on expired access",
+			onExpiry:  types.StringValue("error"),
+			expiresAt: past,
+			expected:  true,
+		},
+		{
+			name:      "keep absent access removed before expiry",
+			onExpiry:  types.StringValue("keep_absent"),
+			expiresAt: future,
+			expected:  false,
+		},
+		{
+			name:      "keep absent access without expiry",
+			onExpiry:  types.StringValue("keep_absent"),
+			expiresAt: types.StringNull(),
+			expected:  false,
+		},
+		{
+			name:      "unset on_expiry",
+			onExpiry:  types.StringNull(),
+			expiresAt: past,
+			expected:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, keepExpiredAccess(tc.onExpiry, tc.expiresAt))
+		})
+	}
+}
+
+func TestCheckExpiredAccessPlan(t *testing.T) {
+	past := types.StringValue(time.Now().Add(-time.Hour).UTC().Format(time.RFC3339))
+	future := types.StringValue(time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+
+	testCases := []struct {
+		name      string
+		onExpiry  string
+		ttl       customtypes.Duration
+		expiresAt types.String
+		plan      func(model *GroupServerResourceModel)
+		expectErr bool
+	}{
+		{
+			name:      "expired access kept",
+			onExpiry:  onExpiryError,
+			ttl:       customtypes.NewDurationValue(bastion.Duration(3600)),
+			expiresAt: past,
+			expectErr: true,
+		},
+		{
+			name:      "expired access with changed ttl",
+			onExpiry:  onExpiryError,
+			ttl:       customtypes.NewDurationValue(bastion.Duration(3600)),
+			expiresAt: past,
+			plan: func(model *GroupServerResourceModel) {
+				model.TTL = customtypes.NewDurationValue(bastion.Duration(7200))
+				model.ExpiresAt = types.StringUnknown()
+			},
+			expectErr: false,
+		},
+		{
+			name:      "expired access with changed expires_at",
+			onExpiry:  onExpiryError,
+			ttl:       customtypes.NewDurationNull(),
+			expiresAt: past,
+			plan: func(model *GroupServerResourceModel) {
+				model.ExpiresAt = future
+			},
+			expectErr: false,
+		},
+		{
+			name:      "expired access recreated",
+			onExpiry:  onExpiryRecreate,
+			ttl:       customtypes.NewDurationValue(bastion.Duration(3600)),
+			expiresAt: past,
+			expectErr: false,
+		},
+		{
+			name:      "access not expired",
+			onExpiry:  onExpiryError,
+			ttl:       customtypes.NewDurationValue(bastion.Duration(3600)),
+			expiresAt: future,
+			expectErr: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			state := testGroupServerModel()
+			state.OnExpiry = types.StringValue(tc.onExpiry)
+			state.TTL = tc.ttl
+			state.ExpiresAt = tc.expiresAt
+
+			plan := state
+			if tc.plan != nil {
+				tc.plan(&plan)
+			}
+
+			req, resp := testGroupServerModifyPlan(t, &plan, &state)
+			checkExpiredAccessPlan(context.Background(), req, resp)
+			assert.Equal(t, tc.expectErr, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+		})
+	}
+}
+
+func TestCheckExpiresAtPlan(t *testing.T) {
+	past := types.StringValue(time.Now().Add(-time.Hour).UTC().Format(time.RFC3339))
+	earlier := types.StringValue(time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339))
+	future := types.StringValue(time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+
+	testCases := []struct {
+		name      string
+		prior     *types.String
+		expiresAt types.String
+		expectErr bool
+	}{
+		{
+			name:      "create in the future",
+			expiresAt: future,
+			expectErr: false,
+		},
+		{
+			name:      "create in the past",
+			expiresAt: past,
+			expectErr: true,
+		},
+		{
+			name:      "unchanged past timestamp",
+			prior:     &past,
+			expiresAt: past,
+			expectErr: false,
+		},
+		{
+			name:      "update to a past timestamp",
+			prior:     &future,
+			expiresAt: past,
+			expectErr: true,
+		},
+		{
+			name:      "update to an earlier past timestamp",
+			prior:     &past,
+			expiresAt: earlier,
+			expectErr: true,
+		},
+		{
+			name:      "update to a future timestamp",
+			prior:     &past,
+			expiresAt: future,
+			expectErr: false,
+		},
+		{
+			name:      "invalid timestamp",
+			expiresAt: types.StringValue("tomorrow"),
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			plan := testGroupServerModel()
+			plan.ExpiresAt = tc.expiresAt
+
+			var state *GroupServerResourceModel
+			if tc.prior != nil {
+				prior := testGroupServerModel()
+				prior.ExpiresAt = *tc.prior
+				state = &prior
+			}
+
+			req, resp := testGroupServerModifyPlan(t, &plan, state)
+			checkExpiresAtPlan(context.Background(), req, resp)
+			assert.Equal(t, tc.expectErr, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+		})
+	}
+}
+
+// testGroupServerModel returns a server access model with the minimal attributes set.
+func testGroupServerModel() GroupServerResourceModel {
+	return GroupServerResourceModel{
+		ID:          types.StringValue("kryptonians:192.168.1.100:22:root"),
+		Group:       types.StringValue("kryptonians"),
+		IP:          customtypes.NewIPAddressValue("192.168.1.100"),
+		ResolvedIPs: types.ListNull(types.StringType),
+		Port:        types.StringValue("22"),
+		User:        types.StringValue("root"),
+		TTL:         customtypes.NewDurationNull(),
+		OnExpiry:    types.StringValue(onExpiryRecreate),
+	}
+}
+
+// testGroupServerModifyPlan builds a ModifyPlan request of the server access resource, the plan being the configuration.
+// A nil state builds the request of a create.
+func testGroupServerModifyPlan(t *testing.T, plan, state *GroupServerResourceModel) (fwresource.ModifyPlanRequest, *fwresource.ModifyPlanResponse) {
+	ctx := context.Background()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	(&GroupServerResource{}).Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	req := fwresource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema},
+		Plan:   tfsdk.Plan{Schema: schemaResp.Schema},
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+
+	diags := req.Plan.Set(ctx, plan)
+	req.Config.Raw = req.Plan.Raw
+	if state != nil {
+		diags.Append(req.State.Set(ctx, state)...)
+	}
+	require.False(t, diags.HasError(), "%v", diags)
+
+	return req, &fwresource.ModifyPlanResponse{Plan: req.Plan}
+}
+
+func TestExpiresAtValue(t *testing.T) {
+	expiry := 1767225600
+	assert.Equal(t, types.StringValue("2026-01-01T00:00:00Z"), expiresAtValue(&expiry))
+	assert.Equal(t, types.StringNull(), expiresAtValue(nil))
+}
+
+func TestRefreshExpiresAt(t *testing.T) {
+	expiry := 1767225600
+
+	testCases := []struct {
+		name     string
+		prior    types.String
+		expiry   *int
+		expected types.String
+	}{
+		{
+			name:     "no expiry",
+			prior:    types.StringValue("2026-01-01T00:00:00Z"),
+			expiry:   nil,
+			expected: types.StringNull(),
+		},
+		{
+			name:     "no prior value",
+			prior:    types.StringNull(),
+			expiry:   &expiry,
+			expected: types.StringValue("2026-01-01T00:00:00Z"),
+		},
+		{
+			name:     "prior value within tolerance",
+			prior:    types.StringValue("2026-01-01T01:00:03+01:00"),
+			expiry:   &expiry,
+			expected: types.StringValue("2026-01-01T01:00:03+01:00"),
+		},
+		{
+			name:     "prior value outside of tolerance",
+			prior:    types.StringValue("2026-01-02T00:00:00Z"),
+			expiry:   &expiry,
+			expected: types.StringValue("2026-01-01T00:00:00Z"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, refreshExpiresAt(tc.prior, tc.expiry))
+		})
+	}
+}
+
+func TestTTLFromExpiresAt(t *testing.T) {
+	ttl, err := ttlFromExpiresAt(types.StringValue(time.Now().Add(time.Hour).UTC().Format(time.RFC3339)))
+	assert.NoError(t, err)
+	assert.InDelta(t, 3600, ttl.Seconds(), 2)
+
+	_, err = ttlFromExpiresAt(types.StringValue(time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)))
+	assert.Error(t, err)
+
+	_, err = ttlFromExpiresAt(types.StringValue("tomorrow"))
+	assert.Error(t, err)
+}
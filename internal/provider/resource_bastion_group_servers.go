@@ -0,0 +1,466 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/adfinis/terraform-provider-bastion/internal/provider/customtypes"
+	"github.com/adfinis/terraform-provider-bastion/internal/provider/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ resource.Resource = &GroupServersResource{}
+var _ resource.ResourceWithConfigure = &GroupServersResource{}
+var _ resource.ResourceWithImportState = &GroupServersResource{}
+var _ resource.ResourceWithValidateConfig = &GroupServersResource{}
+
+// NewGroupServersResource is a helper function to simplify the provider implementation.
+func NewGroupServersResource() resource.Resource {
+	return &GroupServersResource{}
+}
+
+// GroupServersResource is the resource implementation.
+type GroupServersResource struct {
+	client *bastion.Client
+}
+
+// GroupServersResourceModel describes the resource data model.
+type GroupServersResourceModel struct {
+	ID            types.String             `tfsdk:"id"`
+	Group         types.String             `tfsdk:"group"`
+	Servers       []GroupServersEntryModel `tfsdk:"servers"`
+	KeepOnDestroy types.Bool               `tfsdk:"keep_on_destroy"`
+}
+
+// GroupServersEntryModel describes a single server access of the group.
+type GroupServersEntryModel struct {
+	IP            customtypes.IPAddress `tfsdk:"ip"`
+	Port          types.String          `tfsdk:"port"`
+	User          types.String          `tfsdk:"user"`
+	Protocol      types.String          `tfsdk:"protocol"`
+	ProxyIP       customtypes.IPAddress `tfsdk:"proxy_ip"`
+	ProxyPort     types.String          `tfsdk:"proxy_port"`
+	ProxyUser     types.String          `tfsdk:"proxy_user"`
+	Comment       types.String          `tfsdk:"comment"`
+	ForceKey      types.String          `tfsdk:"force_key"`
+	ForcePassword types.String          `tfsdk:"force_password"`
+	RemotePort    types.Int64           `tfsdk:"remote_port"`
+}
+
+// Metadata returns the resource type name.
+func (r *GroupServersResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_servers"
+}
+
+// Schema defines the schema for the resource.
+func (r *GroupServersResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Manages the complete list of server accesses of a Bastion group.
+All changes are applied atomically with ` + "`groupSetServers`" + `, accesses added outside of this resource are removed on the next apply.
+Do not use together with ` + "`bastion_group_server`" + ` on the same group.
+Since ` + "`groupSetServers`" + ` does not verify the accesses and cannot set an expiry, ` + "`force`" + ` is implied and ` + "`ttl`" + ` is not supported.
+
+**Destroying this resource removes every server access of the group**, including the ones which existed before the resource was created or imported, unless ` + "`keep_on_destroy`" + ` is set.`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The resource identifier (group)",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"group": schema.StringAttribute{
+				MarkdownDescription: "The name of the Bastion group",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"keep_on_destroy": schema.BoolAttribute{
+				MarkdownDescription: "Whether to leave the server accesses of the group in place when the resource is destroyed, instead of removing them all. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"servers": schema.SetNestedAttribute{
+				MarkdownDescription: "The server accesses of the group",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"ip": schema.StringAttribute{
+							CustomType:          customtypes.IPAddressType{},
+							MarkdownDescription: "IP or subnet of the access target",
+							Required:            true,
+						},
+						"port": schema.StringAttribute{
+							MarkdownDescription: "Port of the access target, use '*' to allow ssh access to all ports",
+							Required:            true,
+						},
+						"user": schema.StringAttribute{
+							MarkdownDescription: "Username for the access, use '*' to allow ssh access for all users.",
+							Optional:            true,
+						},
+						"protocol": schema.StringAttribute{
+							MarkdownDescription: "Protocol to grant access for. Valid values are 'sftp', 'scpupload', 'scpdownload', 'rsync', 'portforward'. When set, 'user' must be empty.",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("sftp", "scpupload", "scpdownload", "rsync", "portforward"),
+							},
+						},
+						"proxy_ip": schema.StringAttribute{
+							CustomType:          customtypes.IPAddressType{},
+							MarkdownDescription: "IP of the proxy server",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("proxy_port")),
+								stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("proxy_user")),
+							},
+						},
+						"proxy_port": schema.StringAttribute{
+							MarkdownDescription: "Port of the proxy server",
+							Optional:            true,
+						},
+						"proxy_user": schema.StringAttribute{
+							MarkdownDescription: "Username for the proxy server, use '*' to allow all users",
+							Optional:            true,
+						},
+						"comment": schema.StringAttribute{
+							MarkdownDescription: "Comment for the access",
+							Optional:            true,
+						},
+						"force_key": schema.StringAttribute{
+							MarkdownDescription: "Force a specific SSH key for the access",
+							Optional:            true,
+						},
+						"force_password": schema.StringAttribute{
+							MarkdownDescription: "Force a specific password for the access",
+							Optional:            true,
+						},
+						"remote_port": schema.Int64Attribute{
+							MarkdownDescription: "Remote port forwarded from the target server to The Bastion",
+							Optional:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the bastion client to the resource.
+func (r *GroupServersResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*bastion.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *bastion.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ValidateConfig validates the server accesses of the resource configuration.
+func (r *GroupServersResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var servers types.Set
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("servers"), &servers)...)
+	if resp.Diagnostics.HasError() || servers.IsNull() || servers.IsUnknown() {
+		return
+	}
+
+	for _, element := range servers.Elements() {
+		object, ok := element.(types.Object)
+		if !ok || object.IsUnknown() {
+			continue
+		}
+
+		var server GroupServersEntryModel
+		resp.Diagnostics.Append(object.As(ctx, &server, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if server.User.IsUnknown() || server.Protocol.IsUnknown() {
+			continue
+		}
+
+		// either user or protocol must exist but not both
+		if server.User.IsNull() == server.Protocol.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("servers"),
+				"Invalid Group Server Access",
+				fmt.Sprintf("Either 'user' or 'protocol' must be set, but not both, for the access to %s.", server.IP.ValueString()),
+			)
+		}
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *GroupServersResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan GroupServersResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.setServers(plan.Group.ValueString(), plan.Servers)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = plan.Group
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *GroupServersResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state GroupServersResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	servers, err := r.client.GroupListServers(state.Group.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Group Server Accesses",
+			fmt.Sprintf("Could not read server accesses for group %s: %s", state.Group.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	prior := state.Servers
+	state.Servers = make([]GroupServersEntryModel, 0, len(servers))
+	for _, server := range servers {
+		entry := flattenGroupServersEntry(server)
+		keepPriorIPs(&entry, prior)
+		state.Servers = append(state.Servers, entry)
+	}
+	state.ID = state.Group
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *GroupServersResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan GroupServersResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.setServers(plan.Group.ValueString(), plan.Servers)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = plan.Group
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *GroupServersResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state GroupServersResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.KeepOnDestroy.ValueBool() {
+		return
+	}
+
+	// the group keeps no server access once the resource is gone
+	resp.Diagnostics.Append(r.setServers(state.Group.ValueString(), nil)...)
+}
+
+// ImportState imports the resource state.
+func (r *GroupServersResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("group"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("keep_on_destroy"), false)...)
+}
+
+// setServers replaces all server accesses of the group with the given ones.
+func (r *GroupServersResource) setServers(group string, servers []GroupServersEntryModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	entries := make([]bastion.GroupSetServersEntry, 0, len(servers))
+	for _, server := range servers {
+		entry, err := expandGroupServersEntry(&server)
+		if err != nil {
+			diags.AddError(
+				"Invalid Group Server Access",
+				fmt.Sprintf("Invalid access to %s: %s", server.IP.ValueString(), err.Error()),
+			)
+			return diags
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := r.client.GroupSetServers(group, entries); err != nil {
+		diags.AddError(
+			"Error Setting Group Server Accesses",
+			fmt.Sprintf("Could not set server accesses of group %s: %s", group, err.Error()),
+		)
+	}
+
+	return diags
+}
+
+// expandGroupServersEntry converts a server access model to its groupSetServers entry.
+func expandGroupServersEntry(server *GroupServersEntryModel) (bastion.GroupSetServersEntry, error) {
+	entry := bastion.GroupSetServersEntry{
+		IP:            server.IP.ValueCanonical(),
+		ProxyUser:     server.ProxyUser.ValueStringPointer(),
+		Comment:       server.Comment.ValueStringPointer(),
+		ForceKey:      server.ForceKey.ValueStringPointer(),
+		ForcePassword: server.ForcePassword.ValueStringPointer(),
+	}
+
+	if !server.ProxyIP.IsNull() {
+		entry.ProxyIP = utils.ToPtr(server.ProxyIP.ValueCanonical())
+	}
+
+	port, err := parseWildcardPort(server.Port.ValueString())
+	if err != nil {
+		return entry, fmt.Errorf("invalid port: %w", err)
+	}
+	entry.Port = port
+
+	if !server.ProxyPort.IsNull() {
+		proxyPort, err := parseWildcardPort(server.ProxyPort.ValueString())
+		if err != nil {
+			return entry, fmt.Errorf("invalid proxy port: %w", err)
+		}
+		entry.ProxyPort = proxyPort
+	}
+
+	// protocol accesses are stored with a "!protocol" user
+	if !server.Protocol.IsNull() {
+		entry.User = utils.ToPtr("!" + server.Protocol.ValueString())
+	} else if server.User.ValueString() != "*" {
+		entry.User = server.User.ValueStringPointer()
+	}
+
+	if !server.RemotePort.IsNull() {
+		remotePort := int(server.RemotePort.ValueInt64())
+		entry.RemotePort = &remotePort
+	}
+
+	return entry, nil
+}
+
+// flattenGroupServersEntry converts a group server access to its resource model.
+func flattenGroupServersEntry(server *bastion.GroupServer) GroupServersEntryModel {
+	// API returns null for port, user and proxy port when set to "*"
+	entry := GroupServersEntryModel{
+		IP:            customtypes.NewIPAddressValue(server.IP),
+		Port:          types.StringValue("*"),
+		User:          types.StringValue("*"),
+		Protocol:      types.StringNull(),
+		ProxyIP:       customtypes.NewIPAddressPointerValue(server.ProxyIP),
+		ProxyPort:     types.StringNull(),
+		ProxyUser:     types.StringPointerValue(server.ProxyUser),
+		Comment:       types.StringPointerValue(server.UserComment),
+		ForceKey:      types.StringPointerValue(server.ForceKey),
+		ForcePassword: types.StringPointerValue(server.ForcePassword),
+		RemotePort:    types.Int64Null(),
+	}
+
+	if server.Port != nil {
+		entry.Port = types.StringValue(server.Port.ValueString())
+	}
+
+	if server.User != nil && strings.HasPrefix(*server.User, "!") {
+		entry.Protocol = types.StringValue(strings.TrimPrefix(*server.User, "!"))
+		entry.User = types.StringNull()
+	} else if server.User != nil {
+		entry.User = types.StringValue(*server.User)
+	}
+
+	if server.ProxyIP != nil {
+		entry.ProxyPort = types.StringValue("*")
+		if server.ProxyPort != nil {
+			entry.ProxyPort = types.StringValue(server.ProxyPort.ValueString())
+		}
+	}
+
+	if server.RemotePort != nil {
+		entry.RemotePort = types.Int64Value(int64(server.RemotePort.ValueInt()))
+	}
+
+	return entry
+}
+
+// keepPriorIPs keeps the IP and proxy IP of the entry as written in the prior state when the prior entry designates
+// the same access, so that e.g. "10.0.0.1/32" gives no diff against the "10.0.0.1" stored by The Bastion.
+// Semantic equality of the IP type cannot be relied on for set elements, which have no stable order.
+func keepPriorIPs(entry *GroupServersEntryModel, prior []GroupServersEntryModel) {
+	for _, p := range prior {
+		if sameGroupServersEntry(&p, entry) {
+			entry.IP = p.IP
+			entry.ProxyIP = p.ProxyIP
+			return
+		}
+	}
+}
+
+// sameGroupServersEntry checks if both entries designate the same access, comparing their IPs in canonical form.
+func sameGroupServersEntry(a, b *GroupServersEntryModel) bool {
+	if a.IP.IsNull() || !bastion.EqualIP(a.IP.ValueString(), b.IP.ValueString()) {
+		return false
+	}
+	if a.ProxyIP.IsNull() != b.ProxyIP.IsNull() {
+		return false
+	}
+	if !a.ProxyIP.IsNull() && !bastion.EqualIP(a.ProxyIP.ValueString(), b.ProxyIP.ValueString()) {
+		return false
+	}
+
+	return a.Port.Equal(b.Port) &&
+		a.User.Equal(b.User) &&
+		a.Protocol.Equal(b.Protocol) &&
+		a.ProxyPort.Equal(b.ProxyPort) &&
+		a.ProxyUser.Equal(b.ProxyUser) &&
+		a.RemotePort.Equal(b.RemotePort)
+}
+
+// parseWildcardPort parses a port number, returning nil for '*'.
+func parseWildcardPort(port string) (*int, error) {
+	if port == "*" {
+		return nil, nil
+	}
+
+	number, err := strconv.Atoi(port)
+	if err != nil {
+		return nil, err
+	}
+	return &number, nil
+}
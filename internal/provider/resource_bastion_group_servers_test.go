@@ -0,0 +1,207 @@
+// Copyright (c) Adfinis
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/adfinis/terraform-provider-bastion/bastion"
+	"github.com/adfinis/terraform-provider-bastion/internal/provider/customtypes"
+	"github.com/adfinis/terraform-provider-bastion/internal/provider/testutils"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccGroupServersResource(t *testing.T) {
+	err := testutils.CreateGroup("testgrpservers1", "bastionadmin", bastion.ED25519)
+	if err != nil {
+		t.Errorf("Unable to create test group: %s", err)
+	}
+
+	t.Cleanup(func() {
+		err := testutils.DeleteGroup("testgrpservers1")
+		if err != nil {
+			t.Errorf("Unable to delete test group: %s", err)
+		}
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccGroupServersResourceConfig("testgrpservers1", `
+    {
+      ip   = "192.168.1.220"
+      port = "22"
+      user = "root"
+    },
+    {
+      ip      = "192.168.2.0/24"
+      port    = "*"
+      user    = "*"
+      comment = "lab network"
+    },
+`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"bastion_group_servers.test",
+						tfjsonpath.New("id"),
+						knownvalue.StringExact("testgrpservers1"),
+					),
+					statecheck.ExpectKnownValue(
+						"bastion_group_servers.test",
+						tfjsonpath.New("servers"),
+						knownvalue.SetSizeExact(2),
+					),
+				},
+			},
+			// ImportState testing
+			{
+				ResourceName:      "bastion_group_servers.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     "testgrpservers1",
+			},
+			// Access added out of band is removed, a protocol access is added
+			{
+				PreConfig: func() {
+					err := testutils.CreateGroupServerAccess("testgrpservers1", "192.168.1.221", "22", "root")
+					if err != nil {
+						t.Errorf("Unable to create group server access: %s", err)
+					}
+				},
+				Config: testAccGroupServersResourceConfig("testgrpservers1", `
+    {
+      ip   = "192.168.1.220"
+      port = "22"
+      user = "root"
+    },
+    {
+      ip       = "192.168.1.220"
+      port     = "22"
+      protocol = "sftp"
+    },
+`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("bastion_group_servers.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"bastion_group_servers.test",
+						tfjsonpath.New("servers"),
+						knownvalue.SetExact([]knownvalue.Check{
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"ip":       knownvalue.StringExact("192.168.1.220"),
+								"user":     knownvalue.StringExact("root"),
+								"protocol": knownvalue.Null(),
+							}),
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"ip":       knownvalue.StringExact("192.168.1.220"),
+								"user":     knownvalue.Null(),
+								"protocol": knownvalue.StringExact("sftp"),
+							}),
+						}),
+					),
+				},
+			},
+			// The accesses are kept when the resource is destroyed with keep_on_destroy
+			{
+				Config: providerConfig + `
+resource "bastion_group_servers" "test" {
+  group           = "testgrpservers1"
+  keep_on_destroy = true
+  servers = [
+    {
+      ip   = "192.168.1.220"
+      port = "22"
+      user = "root"
+    },
+  ]
+}
+`,
+			},
+			{
+				Config: providerConfig,
+				Check: func(*terraform.State) error {
+					servers, err := testutils.TestBastionClient.GroupListServers("testgrpservers1")
+					if err != nil {
+						return err
+					}
+					if len(servers) != 1 {
+						return fmt.Errorf("expected the server access to be kept, got %d accesses", len(servers))
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+// testAccGroupServersResourceConfig generates the Terraform configuration for testing.
+func testAccGroupServersResourceConfig(groupName, servers string) string {
+	config := providerConfig
+	config += fmt.Sprintf(`
+resource "bastion_group_servers" "test" {
+  group   = %[1]q
+  servers = [%[2]s  ]
+}
+`, groupName, servers)
+
+	return config
+}
+
+func TestKeepPriorIPs(t *testing.T) {
+	entry := func(ip, port string) GroupServersEntryModel {
+		return GroupServersEntryModel{
+			IP:         customtypes.NewIPAddressValue(ip),
+			Port:       types.StringValue(port),
+			User:       types.StringValue("root"),
+			Protocol:   types.StringNull(),
+			ProxyIP:    customtypes.NewIPAddressNull(),
+			ProxyPort:  types.StringNull(),
+			ProxyUser:  types.StringNull(),
+			RemotePort: types.Int64Null(),
+		}
+	}
+
+	// Both accesses share an address but differ in port and spelling
+	prior := []GroupServersEntryModel{
+		entry("10.0.0.1", "22"),
+		entry("10.0.0.1/32", "2222"),
+	}
+
+	ssh := entry("10.0.0.1", "22")
+	keepPriorIPs(&ssh, prior)
+	assert.Equal(t, "10.0.0.1", ssh.IP.ValueString())
+
+	alt := entry("10.0.0.1", "2222")
+	keepPriorIPs(&alt, prior)
+	assert.Equal(t, "10.0.0.1/32", alt.IP.ValueString())
+
+	other := entry("10.0.0.1", "8022")
+	keepPriorIPs(&other, prior)
+	assert.Equal(t, "10.0.0.1", other.IP.ValueString())
+
+	proxied := entry("10.0.0.2", "22")
+	proxied.ProxyIP = customtypes.NewIPAddressValue("192.168.0.1")
+	proxied.ProxyPort = types.StringValue("22")
+	proxied.ProxyUser = types.StringValue("proxy")
+	priorProxied := proxied
+	priorProxied.IP = customtypes.NewIPAddressValue("10.0.0.2/32")
+	priorProxied.ProxyIP = customtypes.NewIPAddressValue("192.168.0.1/32")
+	keepPriorIPs(&proxied, []GroupServersEntryModel{priorProxied})
+	assert.Equal(t, "10.0.0.2/32", proxied.IP.ValueString())
+	assert.Equal(t, "192.168.0.1/32", proxied.ProxyIP.ValueString())
+}
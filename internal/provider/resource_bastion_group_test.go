@@ -10,6 +10,7 @@ import (
 	"github.com/adfinis/terraform-provider-bastion/internal/provider/testutils"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 )
@@ -173,17 +174,17 @@ func TestAccGroupResource_WithModifyOptions(t *testing.T) {
 					statecheck.ExpectKnownValue(
 						"bastion_group.test",
 						tfjsonpath.New("idle_lock_timeout"),
-						knownvalue.Int64Exact(900),
+						knownvalue.StringExact("900"),
 					),
 					statecheck.ExpectKnownValue(
 						"bastion_group.test",
 						tfjsonpath.New("idle_kill_timeout"),
-						knownvalue.Int64Exact(1800),
+						knownvalue.StringExact("1800"),
 					),
 					statecheck.ExpectKnownValue(
 						"bastion_group.test",
 						tfjsonpath.New("guest_ttl_limit"),
-						knownvalue.Int64Exact(86400),
+						knownvalue.StringExact("86400"),
 					),
 				},
 			},
@@ -199,17 +200,17 @@ func TestAccGroupResource_WithModifyOptions(t *testing.T) {
 					statecheck.ExpectKnownValue(
 						"bastion_group.test",
 						tfjsonpath.New("idle_lock_timeout"),
-						knownvalue.Int64Exact(1200),
+						knownvalue.StringExact("1200"),
 					),
 					statecheck.ExpectKnownValue(
 						"bastion_group.test",
 						tfjsonpath.New("idle_kill_timeout"),
-						knownvalue.Int64Exact(2400),
+						knownvalue.StringExact("2400"),
 					),
 					statecheck.ExpectKnownValue(
 						"bastion_group.test",
 						tfjsonpath.New("guest_ttl_limit"),
-						knownvalue.Int64Exact(43200),
+						knownvalue.StringExact("43200"),
 					),
 				},
 			},
@@ -272,12 +273,12 @@ func TestAccGroupResource_PartialModifyOptions(t *testing.T) {
 					statecheck.ExpectKnownValue(
 						"bastion_group.test",
 						tfjsonpath.New("idle_lock_timeout"),
-						knownvalue.Int64Exact(600),
+						knownvalue.StringExact("600"),
 					),
 					statecheck.ExpectKnownValue(
 						"bastion_group.test",
 						tfjsonpath.New("idle_kill_timeout"),
-						knownvalue.Int64Exact(1201),
+						knownvalue.StringExact("1201"),
 					),
 				},
 			},
@@ -362,7 +363,7 @@ func TestAccGroupResource_IdleLockTimeout(t *testing.T) {
 					statecheck.ExpectKnownValue(
 						"bastion_group.test",
 						tfjsonpath.New("idle_lock_timeout"),
-						knownvalue.Int64Exact(600),
+						knownvalue.StringExact("600"),
 					),
 				},
 			},
@@ -375,7 +376,7 @@ func TestAccGroupResource_IdleLockTimeout(t *testing.T) {
 					statecheck.ExpectKnownValue(
 						"bastion_group.test",
 						tfjsonpath.New("idle_lock_timeout"),
-						knownvalue.Int64Exact(1200),
+						knownvalue.StringExact("1200"),
 					),
 				},
 			},
@@ -388,7 +389,7 @@ func TestAccGroupResource_IdleLockTimeout(t *testing.T) {
 					statecheck.ExpectKnownValue(
 						"bastion_group.test",
 						tfjsonpath.New("idle_lock_timeout"),
-						knownvalue.Int64Exact(0),
+						knownvalue.StringExact("0"),
 					),
 				},
 			},
@@ -401,7 +402,7 @@ func TestAccGroupResource_IdleLockTimeout(t *testing.T) {
 					statecheck.ExpectKnownValue(
 						"bastion_group.test",
 						tfjsonpath.New("idle_lock_timeout"),
-						knownvalue.Int64Exact(-1),
+						knownvalue.StringExact("-1"),
 					),
 				},
 			},
@@ -414,7 +415,31 @@ func TestAccGroupResource_IdleLockTimeout(t *testing.T) {
 					statecheck.ExpectKnownValue(
 						"bastion_group.test",
 						tfjsonpath.New("idle_lock_timeout"),
-						knownvalue.Int64Exact(900),
+						knownvalue.StringExact("900"),
+					),
+				},
+			},
+			// An equal duration gives no diff
+			{
+				Config: testAccGroupResourceConfigWithPartialOptions("testgrp9", "bastionadmin", "", map[string]any{
+					"idle_lock_timeout": "15m",
+				}),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// Durations are accepted
+			{
+				Config: testAccGroupResourceConfigWithPartialOptions("testgrp9", "bastionadmin", "", map[string]any{
+					"idle_lock_timeout": "1h30m",
+				}),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"bastion_group.test",
+						tfjsonpath.New("idle_lock_timeout"),
+						knownvalue.StringExact("1h30m"),
 					),
 				},
 			},
@@ -466,7 +491,7 @@ func TestAccGroupResource_OwnershipTransfer(t *testing.T) {
 					),
 				},
 			},
-			// Transfer ownership to testuser1
+			// Transmit ownership to testuser1
 			{
 				Config: testAccGroupResourceConfig("testgrp10", "testuser1", ""),
 				ConfigStateChecks: []statecheck.StateCheck{
@@ -475,9 +500,21 @@ func TestAccGroupResource_OwnershipTransfer(t *testing.T) {
 						tfjsonpath.New("owner"),
 						knownvalue.StringExact("testuser1"),
 					),
+					statecheck.ExpectKnownValue(
+						"bastion_group.test",
+						tfjsonpath.New("owner_change_strategy"),
+						knownvalue.StringExact("transmit"),
+					),
+					statecheck.ExpectKnownValue(
+						"bastion_group.test",
+						tfjsonpath.New("owners"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.StringExact("testuser1"),
+						}),
+					),
 				},
 			},
-			// Transfer ownership back to bastionadmin
+			// Transfer ownership back to bastionadmin, transmit falls back to add_then_remove as bastionadmin is no longer an owner
 			{
 				Config: testAccGroupResourceConfig("testgrp10", "bastionadmin", ""),
 				ConfigStateChecks: []statecheck.StateCheck{
@@ -486,6 +523,57 @@ func TestAccGroupResource_OwnershipTransfer(t *testing.T) {
 						tfjsonpath.New("owner"),
 						knownvalue.StringExact("bastionadmin"),
 					),
+					statecheck.ExpectKnownValue(
+						"bastion_group.test",
+						tfjsonpath.New("owners"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.StringExact("bastionadmin"),
+						}),
+					),
+				},
+			},
+			// Transfer ownership to testuser1 and back with add_then_remove
+			{
+				Config: testAccGroupResourceConfigOwnerChangeStrategy("testgrp10", "testuser1", "add_then_remove"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"bastion_group.test",
+						tfjsonpath.New("owners"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.StringExact("testuser1"),
+						}),
+					),
+				},
+			},
+			{
+				Config: testAccGroupResourceConfigOwnerChangeStrategy("testgrp10", "bastionadmin", "add_then_remove"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"bastion_group.test",
+						tfjsonpath.New("owners"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.StringExact("bastionadmin"),
+						}),
+					),
+				},
+			},
+			// Add testuser1 as owner and keep bastionadmin
+			{
+				Config: testAccGroupResourceConfigOwnerChangeStrategy("testgrp10", "testuser1", "add_only"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"bastion_group.test",
+						tfjsonpath.New("owner"),
+						knownvalue.StringExact("testuser1"),
+					),
+					statecheck.ExpectKnownValue(
+						"bastion_group.test",
+						tfjsonpath.New("owners"),
+						knownvalue.SetExact([]knownvalue.Check{
+							knownvalue.StringExact("bastionadmin"),
+							knownvalue.StringExact("testuser1"),
+						}),
+					),
 				},
 			},
 		},
@@ -580,6 +668,17 @@ resource "bastion_group" "test" {
 	return config
 }
 
+// testAccGroupResourceConfigOwnerChangeStrategy generates config with the given owner change strategy.
+func testAccGroupResourceConfigOwnerChangeStrategy(groupName, owner, strategy string) string {
+	return providerConfig + fmt.Sprintf(`
+resource "bastion_group" "test" {
+  group                 = %[1]q
+  owner                 = %[2]q
+  owner_change_strategy = %[3]q
+}
+`, groupName, owner, strategy)
+}
+
 // testAccGroupResourceConfigWithModifyOptions generates config with all modify options.
 func testAccGroupResourceConfigWithModifyOptions(groupName, owner, keyAlgo, mfaRequired string, idleLockTimeout, idleKillTimeout, guestTtlLimit int) string {
 	config := providerConfig
@@ -624,6 +723,9 @@ resource "bastion_group" "test" {
 	if idleLockTimeout, ok := options["idle_lock_timeout"].(int); ok {
 		resourceConfig += fmt.Sprintf("  idle_lock_timeout = %d\n", idleLockTimeout)
 	}
+	if idleLockTimeout, ok := options["idle_lock_timeout"].(string); ok {
+		resourceConfig += fmt.Sprintf("  idle_lock_timeout = %q\n", idleLockTimeout)
+	}
 
 	if idleKillTimeout, ok := options["idle_kill_timeout"].(int); ok {
 		resourceConfig += fmt.Sprintf("  idle_kill_timeout = %d\n", idleKillTimeout)